@@ -1,5 +1,7 @@
 package main
 
+import "math"
+
 // Field represents a pixel of the arena
 type Field struct {
 	player Player
@@ -39,3 +41,85 @@ func (b *Board) isValidMove(fromX, fromY, toX, toY int) bool {
 func (f *Field) setUsed(p Player) {
 	f.player = p
 }
+
+// linePath walks the pixels of a Bresenham line that starts at a given
+// point and heads in the given direction
+type linePath struct {
+	x, y   int
+	x1, y1 int
+	dx, dy int
+	sx, sy int
+	err    int
+}
+
+func newLinePath(x0, y0 int, rotationRad float64) *linePath {
+	x1 := int(float64(x0) + math.Cos(rotationRad)*1000)
+	y1 := int(float64(y0) + math.Sin(rotationRad)*1000)
+
+	dx := int(math.Abs(float64(x1 - x0)))
+	dy := -(int(math.Abs(float64(y1 - y0))))
+	sx := 1
+	if x1 < x0 {
+		sx = -1
+	}
+	sy := 1
+	if y1 < y0 {
+		sy = -1
+	}
+	return &linePath{x0, y0, x1, y1, dx, dy, sx, sy, dx + dy}
+}
+
+// next advances the path by one pixel and returns
+// the previous and the new position
+func (l *linePath) next() (fromX, fromY, toX, toY int) {
+	fromX = l.x
+	fromY = l.y
+	e2 := 2 * l.err
+	if e2 >= l.dy {
+		if l.x != l.x1 {
+			l.err += l.dy
+			l.x += l.sx
+		}
+	}
+	if e2 <= l.dx {
+		if l.y != l.y1 {
+			l.err += l.dx
+			l.y += l.sy
+		}
+	}
+	return fromX, fromY, l.x, l.y
+}
+
+// Pixels a player travels in a single move
+const pixelsPerMove = 3
+
+// move walks the player a single move along its heading, marking the fields
+// with its trace if it leaves one. It calls reached with every position and
+// returns false when the player crashed.
+func (b *Board) move(p Player, x0, y0 int, rotationRad float64, trace bool, reached func(x, y int)) bool {
+	path := newLinePath(x0, y0, rotationRad)
+	for i := 0; i < pixelsPerMove; i++ {
+		fromX, fromY, x, y := path.next()
+		if !b.isValidMove(fromX, fromY, x, y) {
+			return false
+		}
+		if trace {
+			b.fields[x][y].setUsed(p)
+		}
+		reached(x, y)
+	}
+	return true
+}
+
+// distanceToWall returns the number of pixels that can be travelled from
+// the given point in the given direction before hitting a wall or a trace
+func (b *Board) distanceToWall(x0, y0, rotationDeg int) int {
+	distance := 0
+	path := newLinePath(x0, y0, float64(rotationDeg)*math.Pi/180)
+	for {
+		if !b.isValidMove(path.next()) {
+			return distance
+		}
+		distance++
+	}
+}
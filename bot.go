@@ -1,16 +1,20 @@
 package main
 
 import (
-	"encoding/json"
-	"log"
 	"math"
 	"sync"
 	"time"
+
+	"github.com/lazareviczoran/blaster-twister/protocol"
 )
 
 // Bot represents the computer player
 type Bot struct {
 	PlayerData
+	personality Personality
+	difficulty  Difficulty
+	// angles decided in the previous ticks, used for delayed reactions
+	decisions []int
 }
 
 type intersection struct {
@@ -23,12 +27,6 @@ func (b *Bot) ID() int {
 	return b.id
 }
 
-// ClientID returns the id obtained from the WS client,
-// in this case we return -1 since the bot is not a WS client
-func (b *Bot) ClientID() int {
-	return b.clientID
-}
-
 // Game returns the pointer to Game
 func (b *Bot) Game() *Game {
 	return b.game
@@ -50,10 +48,8 @@ func (b *Bot) SetAlive(alive bool) {
 	b.alive = alive
 }
 
-// Broadcast sends the message to writePump, which eventually sends it
-// to the websocket client
-func (b *Bot) Broadcast(message []byte) {
-	b.send <- message
+// Broadcast drops the message, bots don't have a connection
+func (b *Bot) Broadcast(msg protocol.Message) {
 }
 
 // Destroy closes all channels and removes player from the game
@@ -77,8 +73,7 @@ func (b *Bot) InitPlayer() {
 		}
 	}()
 
-	startX := width / 2
-	startY := height/5 + b.id*3*height/5
+	startX, startY := startPosition(b.id, b.game.capacity)
 	b.currentPosition.Store("x", startX)
 	b.currentPosition.Store("y", startY)
 	b.currentPosition.Store("rotation", getStartRotation())
@@ -90,7 +85,7 @@ func (b *Bot) InitPlayer() {
 // the players rotation angle on each tick
 func (b *Bot) StartRotation(direction string) {
 	b.StopRotation()
-	b.rotationTicker = time.NewTicker(30 * time.Millisecond)
+	b.rotationTicker = time.NewTicker(rotationInterval)
 	go func() {
 		for {
 			select {
@@ -98,9 +93,9 @@ func (b *Bot) StartRotation(direction string) {
 				currRotation, _ := b.currentPosition.Load("rotation")
 
 				if direction == directionRight {
-					b.currentPosition.Store("rotation", (currRotation.(int)+5)%360)
+					b.currentPosition.Store("rotation", (currRotation.(int)+rotationStep)%360)
 				} else {
-					b.currentPosition.Store("rotation", (currRotation.(int)+355)%360)
+					b.currentPosition.Store("rotation", (currRotation.(int)+360-rotationStep)%360)
 				}
 				b.currentPosition.Store("rotationDir", direction)
 			case <-b.stopRotation:
@@ -120,21 +115,12 @@ func (b *Bot) StopRotation() {
 	}
 }
 
-// BroadcastCurrentPosition sends the players current position
-// to all clients
-func (b *Bot) BroadcastCurrentPosition() {
-	temp := make(map[string]interface{})
-	playersStatusMap := make(map[int]interface{})
-	playersStatusMap[b.id] = syncMapToMap(b.currentPosition)
-	temp["players"] = playersStatusMap
-
-	res, err := json.Marshal(&temp)
-	if err != nil {
-		log.Printf("Could not convert to JSON, %v", err)
-		return
-	}
-
-	b.game.sendToAll(res)
+// State returns the players current position
+func (b *Bot) State() protocol.PlayerState {
+	state := positionState(b.currentPosition)
+	state.Bot = true
+	state.Personality = string(b.personality)
+	return state
 }
 
 // Move sets the new position for the player calculated by
@@ -148,9 +134,7 @@ func (b *Bot) Move() {
 	for {
 		select {
 		case <-mainTicker.C:
-			if !b.game.started {
-				b.BroadcastCurrentPosition()
-			} else {
+			if b.game.started {
 				if !b.alive || b.game.winner != nil {
 					visitedTicker.Stop()
 					return
@@ -160,7 +144,8 @@ func (b *Bot) Move() {
 				curRotation, _ := b.currentPosition.Load("rotation")
 				curRotationDir, _ := b.currentPosition.Load("rotationDir")
 
-				angle := b.findAngleToFarthestIntersection(curX.(int), curY.(int))
+				angle, decision := b.chooseAngle(curX.(int), curY.(int), curRotation.(int))
+				b.game.debug.publish(decision)
 				go func() {
 					diff := angle - curRotation.(int)
 					if diff > 0 {
@@ -201,65 +186,39 @@ func (b *Bot) Move() {
 	}
 }
 
-func (b *Bot) findAngleToFarthestIntersection(x0, y0 int) int {
+// findAngleToFarthestIntersection returns the angle with the longest
+// distance to a wall
+func findAngleToFarthestIntersection(distances []int) int {
+	farthest := 0
+	for i, distance := range distances {
+		if distance > distances[farthest] {
+			farthest = i
+		}
+	}
+	return farthest * 10
+}
+
+// getDistancesToWalls returns the distances to the closest wall
+// in 10 degree steps
+func (b *Bot) getDistancesToWalls(x0, y0 int) []int {
 	channel := make(chan *intersection)
 	for i := 0; i < 36; i++ {
 		go b.getDistanceToWall(channel, x0, y0, i*10)
 	}
-	var farthestIntersection *intersection
-	finished := 0
-	for {
-		select {
-		case intersection := <-channel:
-			if farthestIntersection == nil || farthestIntersection.distance < intersection.distance {
-				farthestIntersection = intersection
-			}
-			finished++
-			if finished < 36 {
-				continue
-			}
-			return farthestIntersection.angle
-		}
+	distances := make([]int, 36)
+	for i := 0; i < 36; i++ {
+		intersection := <-channel
+		distances[intersection.angle/10] = intersection.distance
 	}
+	return distances
 }
 
+// getDistanceToWall sends the distance to the closest wall in the given
+// direction, distances beyond the bots look-ahead are not distinguished
 func (b *Bot) getDistanceToWall(channel chan *intersection, x0, y0, rotationDeg int) {
-	distance := 0
-	rotationRad := float64(rotationDeg) * math.Pi / 180
-	x1 := int(float64(x0) + math.Cos(rotationRad)*1000)
-	y1 := int(float64(y0) + math.Sin(rotationRad)*1000)
-
-	dx := int(math.Abs(float64(x1 - x0)))
-	dy := -(int(math.Abs(float64(y1 - y0))))
-	sx := 1
-	if x1 < x0 {
-		sx = -1
-	}
-	sy := 1
-	if y1 < y0 {
-		sy = -1
-	}
-	err := dx + dy
-	for {
-		fromX := x0
-		fromY := y0
-		e2 := 2 * err
-		if e2 >= dy {
-			if x0 != x1 {
-				err += dy
-				x0 += sx
-			}
-		}
-		if e2 <= dx {
-			if y0 != y1 {
-				err += dx
-				y0 += sy
-			}
-		}
-		if !b.game.board.isValidMove(fromX, fromY, x0, y0) {
-			channel <- &intersection{distance, rotationDeg}
-			return
-		}
-		distance++
+	distance := b.game.board.distanceToWall(x0, y0, rotationDeg)
+	if distance > b.difficulty.LookAhead {
+		distance = b.difficulty.LookAhead
 	}
+	channel <- &intersection{distance, rotationDeg}
 }
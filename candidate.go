@@ -2,43 +2,149 @@ package main
 
 import (
 	"log"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/lazareviczoran/blaster-twister/protocol"
 )
 
+// Timeout of the answer to a ping of the lobby
+const candidateCheckTimeout = 200 * time.Millisecond
+
 // Candidate represents a potential player which is waiting in the lobby
 type Candidate struct {
-	send    chan []byte
-	receive chan []byte
+	// messages to the candidate, nil closes the connection
+	send chan protocol.Message
+	// round trip times of the answers to the pings of the lobby
+	receive chan time.Duration
+	// closed when the connection is lost
+	done    chan struct{}
 	conn    *websocket.Conn
+	lobby   Matchmaker
+	session string
+	rating  Rating
+	// how long IsConnected waits for the answer to a ping
+	checkTimeout time.Duration
+	// id of the candidate across the servers sharing the lobby,
+	// and the server holding the connection of a remote candidate
+	key  string
+	node string
+	// profile of a remote candidate, its server keeps the profiles
+	remoteProfile *protocol.PlayerProfile
+	// party of the candidate and its id in there, only
+	// accessed by the goroutine running the lobby
+	party *Party
+	id    int
+	// closes the connection of a candidate lagging behind once
+	kick sync.Once
 }
 
-func newCandidate(conn *websocket.Conn) Candidate {
-	c := Candidate{make(chan []byte), make(chan []byte), conn}
+func newCandidate(l Matchmaker, conn *websocket.Conn, session string) *Candidate {
+	c := &Candidate{
+		send:         make(chan protocol.Message, 8),
+		receive:      make(chan time.Duration, 1),
+		done:         make(chan struct{}),
+		conn:         conn,
+		checkTimeout: candidateCheckTimeout,
+		lobby:        l,
+		session:      session,
+		rating:       ratings.get(session),
+		key:          randToken(),
+	}
 	go c.writePump()
 	go c.readPump()
 	return c
 }
 
-func (c *Candidate) IsConnected() bool {
-	timeoutTicker := time.NewTicker(200 * time.Millisecond)
-	defer func() {
-		timeoutTicker.Stop()
-	}()
-	c.send <- []byte("ping")
-	for {
-		select {
-		case <-c.receive:
-			return true
-		case <-timeoutTicker.C:
-			return false
-		}
+// IsConnected pings the candidate and waits for its answer,
+// it returns the round trip time of the candidates connection
+func (c *Candidate) IsConnected() (time.Duration, bool) {
+	// drop late answers of earlier pings
+	select {
+	case <-c.receive:
+	default:
+	}
+	if !c.Send(protocol.Ping{Time: unixMillis(time.Now())}) {
+		return 0, false
+	}
+	timeout := time.NewTimer(c.checkTimeout)
+	defer timeout.Stop()
+	select {
+	case rtt := <-c.receive:
+		return rtt, true
+	case <-timeout.C:
+		return 0, false
+	case <-c.done:
+		return 0, false
+	}
+}
+
+// answer passes the answer to a ping to IsConnected
+func (c *Candidate) answer(rtt time.Duration) {
+	select {
+	case c.receive <- rtt:
+	default:
+	}
+}
+
+// profile returns how the candidate is shown to the others
+func (c *Candidate) profile() protocol.PlayerProfile {
+	if c.remoteProfile != nil {
+		return *c.remoteProfile
 	}
+	return profiles.get(c.session)
 }
 
-func (c *Candidate) Redirect(message []byte) {
-	c.send <- message
+// Send queues the message and returns whether it was queued. It never
+// blocks, the lobby can't wait for a single candidate, so the connection
+// of a candidate lagging behind with a full queue is closed instead.
+func (c *Candidate) Send(msg protocol.Message) bool {
+	select {
+	case <-c.done:
+		return false
+	default:
+	}
+	select {
+	case c.send <- msg:
+		return true
+	default:
+		c.drop()
+		return false
+	}
+}
+
+// Notify queues the message unless the candidate is lagging behind,
+// it is used for updates which are replaced by the next one
+func (c *Candidate) Notify(msg protocol.Message) {
+	select {
+	case c.send <- msg:
+	default:
+	}
+}
+
+// Leave sends the last message to the candidate and closes its connection
+func (c *Candidate) Leave(msg protocol.Message) {
+	if c.Send(msg) {
+		c.Close()
+	}
+}
+
+// Close closes the connection of the candidate once its messages are sent
+func (c *Candidate) Close() {
+	c.Send(nil)
+}
+
+// drop closes the connection of a candidate lagging behind, its reader
+// then disconnects it from the lobby. The messages to a remote candidate
+// are dropped, as it has no connection on this server.
+func (c *Candidate) drop() {
+	c.kick.Do(func() {
+		log.Printf("Dropping candidate %s, it is lagging behind", c.key)
+		if c.conn != nil {
+			c.conn.Close()
+		}
+	})
 }
 
 // readPump pumps messages from the websocket connection to the hub.
@@ -47,6 +153,11 @@ func (c *Candidate) Redirect(message []byte) {
 // ensures that there is at most one reader on a connection by executing all
 // reads from this goroutine.
 func (c *Candidate) readPump() {
+	defer func() {
+		close(c.done)
+		c.conn.Close()
+		c.lobby.Disconnect(c)
+	}()
 	c.conn.SetReadLimit(maxMessageSize)
 	c.conn.SetReadDeadline(time.Now().Add(pongWait))
 	c.conn.SetPongHandler(func(string) error { c.conn.SetReadDeadline(time.Now().Add(pongWait)); return nil })
@@ -58,7 +169,31 @@ func (c *Candidate) readPump() {
 			}
 			break
 		}
-		c.receive <- message
+		msg, err := protocol.Decode(message)
+		if err != nil {
+			log.Printf("Invalid lobby message, %v", err)
+			continue
+		}
+		switch msg := msg.(type) {
+		case *protocol.Pong:
+			c.lobby.Pong(c, time.Duration(unixMillis(time.Now())-msg.Time)*time.Millisecond)
+		case *protocol.Queue:
+			c.lobby.Queue(c)
+		case *protocol.Cancel:
+			c.lobby.Cancel(c)
+		case *protocol.Profile:
+			if _, err := profiles.set(c.session, msg.PlayerProfile); err != nil {
+				c.Notify(protocol.Error{Error: err.Error()})
+			}
+		case *protocol.Ready:
+			if msg.Ready {
+				c.lobby.Confirm(c)
+			} else {
+				c.lobby.Decline(c)
+			}
+		default:
+			c.Notify(protocol.Error{Error: "Unexpected " + msg.MessageType() + " message"})
+		}
 	}
 }
 
@@ -75,21 +210,18 @@ func (c *Candidate) writePump() {
 	}()
 	for {
 		select {
-		case message, ok := <-c.send:
+		case msg := <-c.send:
 			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
-			if !ok {
-				// The hub closed the channel.
+			if msg == nil {
 				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
 				return
 			}
-
-			w, err := c.conn.NextWriter(websocket.TextMessage)
+			data, err := protocol.Encode(msg)
 			if err != nil {
-				return
+				log.Printf("Could not encode %s message, %v", msg.MessageType(), err)
+				continue
 			}
-			w.Write(message)
-
-			if err := w.Close(); err != nil {
+			if err := c.conn.WriteMessage(websocket.TextMessage, data); err != nil {
 				return
 			}
 		case <-ticker.C:
@@ -0,0 +1,448 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lazareviczoran/blaster-twister/store"
+)
+
+var storeAddr = flag.String("store", "", "address of a Redis compatible store shared with the other servers, by default the lobby and the games stay in memory")
+
+var nodeURL = flag.String("node-url", "", "base URL under which the players reach this server directly, required with -store")
+
+var nodeRegion = flag.String("region", "", "region of this server, games are hosted close to their players")
+
+const (
+	// Interval of announcing the server and its listed games
+	announceInterval = 2 * time.Second
+	// Servers which didn't announce themselves for this long are gone
+	nodeTimeout = 10 * time.Second
+	// Estimated additional round trip to a server in another region
+	crossRegionLatency = 100 * time.Millisecond
+	// Time another server has to create the game of a match
+	hostTimeout = 5 * time.Second
+	// Number of changes of the games of this server which
+	// may wait to be written to the store
+	sharedChanges = 256
+)
+
+// Keys of the store
+const (
+	// hash of the servers by their ids
+	nodesKey = "bt:nodes"
+	// hash of the ids of the servers hosting the games by the game ids
+	gamesKey = "bt:games"
+	// hash of the listed games by their ids
+	listedKey = "bt:listed"
+	// list of the events of the shared lobby
+	lobbyKey = "bt:lobby"
+	// id of the server running the shared lobby
+	lobbyLeaseKey = "bt:lobby:lease"
+	// prefix of the lists of messages to each server
+	mailboxPrefix = "bt:mailbox:"
+)
+
+// cluster connects this server with the others sharing the store,
+// it is nil when the server keeps everything in memory
+var cluster *Cluster
+
+// Cluster is a group of servers which share a lobby and the game browser
+// through the store. Each game runs on a single server, the players are
+// sent there when they are matched or open the game.
+type Cluster struct {
+	// the server itself, whose Seen time is only set in announcements
+	self clusterNode
+	// address of the store
+	addr  string
+	store *store.Client
+	// relay of the shared lobby, which gets the messages for
+	// the candidates connected to this server
+	relay *lobbyRelay
+	// games other servers are asked to create, with the
+	// channels waiting for the result by the game ids
+	hostingMu sync.Mutex
+	hosting   map[string]chan error
+}
+
+// clusterNode is a server of the cluster
+type clusterNode struct {
+	ID     string `json:"id"`
+	URL    string `json:"url"`
+	Region string `json:"region,omitempty"`
+	// when the server last announced itself, in milliseconds since the epoch
+	Seen int64 `json:"seen"`
+}
+
+// nodeMessage is sent to the mailbox of a server
+type nodeMessage struct {
+	// "send" and "close" are for a candidate connected to the server,
+	// "host" asks the server to create a game and "hosted" answers it
+	Kind    string          `json:"kind"`
+	Key     string          `json:"key,omitempty"`
+	Message json.RawMessage `json:"message,omitempty"`
+	Game    *lobbyGame      `json:"game,omitempty"`
+	// server asking for the game, and why the game couldn't be created
+	ReplyTo string `json:"replyTo,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// initCluster joins the cluster of the servers sharing the store, if any
+func initCluster() error {
+	if *storeAddr == "" {
+		return nil
+	}
+	if *nodeURL == "" {
+		return errors.New("The -node-url of the server is required with -store")
+	}
+	cl := newCluster(*storeAddr, clusterNode{ID: randToken(), URL: strings.TrimRight(*nodeURL, "/"), Region: *nodeRegion})
+	if err := cl.announce(); err != nil {
+		return err
+	}
+	log.Printf("Joined the cluster as server %s at %s", cl.self.ID, cl.self.URL)
+	cluster = cl
+	registry := newSharedRegistry(cl)
+	activeGames = registry
+	go registry.share()
+	go cl.run()
+	go cl.receive()
+	return nil
+}
+
+func newCluster(addr string, self clusterNode) *Cluster {
+	return &Cluster{self: self, addr: addr, store: store.NewClient(addr), hosting: make(map[string]chan error)}
+}
+
+// run announces the server and its listed games to the others
+func (cl *Cluster) run() {
+	ticker := time.NewTicker(announceInterval)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		if err := cl.announce(); err != nil {
+			log.Printf("Could not announce the server, %v", err)
+		}
+		if registry, ok := activeGames.(*sharedRegistry); ok {
+			registry.publish(now)
+		}
+	}
+}
+
+func (cl *Cluster) announce() error {
+	node := cl.self
+	node.Seen = unixMillis(time.Now())
+	data, err := json.Marshal(node)
+	if err != nil {
+		return err
+	}
+	return cl.store.HSet(nodesKey, node.ID, string(data))
+}
+
+// nodes returns the servers which are alive by their ids
+func (cl *Cluster) nodes() (map[string]clusterNode, error) {
+	fields, err := cl.store.HGetAll(nodesKey)
+	if err != nil {
+		return nil, err
+	}
+	seenSince := unixMillis(time.Now().Add(-nodeTimeout))
+	nodes := make(map[string]clusterNode, len(fields))
+	for id, data := range fields {
+		var node clusterNode
+		if err := json.Unmarshal([]byte(data), &node); err != nil || node.Seen < seenSince {
+			continue
+		}
+		nodes[id] = node
+	}
+	return nodes, nil
+}
+
+// post puts the message into the mailbox of the server
+func (cl *Cluster) post(node string, msg nodeMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return cl.store.RPush(mailboxPrefix+node, string(data))
+}
+
+// receive handles the messages in the mailbox of this server, it waits
+// for them with a connection of its own
+func (cl *Cluster) receive() {
+	mailbox := store.NewClient(cl.addr)
+	for {
+		data, err := mailbox.BLPop(mailboxPrefix+cl.self.ID, time.Second)
+		if err == store.ErrNil {
+			continue
+		}
+		if err != nil {
+			log.Printf("Could not read the mailbox, %v", err)
+			time.Sleep(time.Second)
+			continue
+		}
+		var msg nodeMessage
+		if err := json.Unmarshal([]byte(data), &msg); err != nil {
+			log.Printf("Invalid message %s in the mailbox, %v", data, err)
+			continue
+		}
+		switch msg.Kind {
+		case "send":
+			cl.relay.deliver(msg.Key, msg.Message)
+		case "close":
+			cl.relay.close(msg.Key)
+		case "host":
+			if msg.Game != nil {
+				cl.host(*msg.Game, msg.ReplyTo)
+			}
+		case "hosted":
+			cl.hosted(msg.Key, msg.Error)
+		}
+	}
+}
+
+// hostGame creates the game of a match on the server closest to its
+// players and returns the base URL of that server. It waits until
+// another server has created the game.
+func (cl *Cluster) hostGame(game lobbyGame, players []matchPlayer) (string, error) {
+	nodes, err := cl.nodes()
+	if err != nil {
+		return "", err
+	}
+	host := selectHost(nodes, players, cl.self)
+	for _, p := range players {
+		if p.node != host.ID {
+			// the sessions and ratings of the players stay on their servers
+			game.Rated = false
+		}
+	}
+	if host.ID == cl.self.ID {
+		if _, err := hostLocally(game, players); err != nil {
+			return "", err
+		}
+		return cl.self.URL, nil
+	}
+
+	result := make(chan error, 1)
+	cl.hostingMu.Lock()
+	cl.hosting[game.ID] = result
+	cl.hostingMu.Unlock()
+	defer func() {
+		cl.hostingMu.Lock()
+		delete(cl.hosting, game.ID)
+		cl.hostingMu.Unlock()
+	}()
+	if err := cl.post(host.ID, nodeMessage{Kind: "host", Game: &game, ReplyTo: cl.self.ID}); err != nil {
+		return "", err
+	}
+	select {
+	case err := <-result:
+		if err != nil {
+			return "", err
+		}
+	case <-time.After(hostTimeout):
+		return "", fmt.Errorf("Server %s didn't create the game in time", host.ID)
+	}
+	log.Printf("Game %s is hosted by server %s", game.ID, host.ID)
+	return host.URL, nil
+}
+
+// host creates the game another server asked for and tells it the result
+func (cl *Cluster) host(game lobbyGame, replyTo string) {
+	reply := nodeMessage{Kind: "hosted", Key: game.ID}
+	if _, err := hostLocally(game, nil); err != nil {
+		log.Printf("Could not host game %s, %v", game.ID, err)
+		reply.Error = err.Error()
+	}
+	if err := cl.post(replyTo, reply); err != nil {
+		log.Printf("Could not answer server %s about game %s, %v", replyTo, game.ID, err)
+	}
+}
+
+// hosted passes the answer of the server hosting the game to the match
+// waiting for it, answers which come too late are dropped
+func (cl *Cluster) hosted(gameID, errMessage string) {
+	cl.hostingMu.Lock()
+	result := cl.hosting[gameID]
+	cl.hostingMu.Unlock()
+	if result == nil {
+		return
+	}
+	var err error
+	if errMessage != "" {
+		err = errors.New(errMessage)
+	}
+	select {
+	case result <- err:
+	default:
+	}
+}
+
+// selectHost picks the server of one of the players with the lowest total
+// estimated round trip time to the players, ties go to the server with more
+// of them. The round trip to the server a player is connected to is known,
+// other servers in the same region are assumed to be as close and servers
+// in other regions crossRegionLatency further away.
+func selectHost(nodes map[string]clusterNode, players []matchPlayer, fallback clusterNode) clusterNode {
+	best, bestTotal, bestPlayers := fallback, time.Duration(-1), 0
+	for id, node := range nodes {
+		var total time.Duration
+		local := 0
+		for _, p := range players {
+			total += p.rtt
+			if p.node == id {
+				local++
+			} else if nodes[p.node].Region != node.Region {
+				total += crossRegionLatency
+			}
+		}
+		if local == 0 {
+			continue
+		}
+		better := bestTotal < 0 || total < bestTotal ||
+			(total == bestTotal && (local > bestPlayers || (local == bestPlayers && id < best.ID)))
+		if better {
+			best, bestTotal, bestPlayers = node, total, local
+		}
+	}
+	return best
+}
+
+// sharedRegistry keeps the games of this server in memory like the
+// gameRegistry, and tells the other servers through the store which
+// server hosts each game and which games are listed. The games are
+// written to the store by share, so the games never wait for the store.
+type sharedRegistry struct {
+	*gameRegistry
+	cluster *Cluster
+	changes chan registryChange
+}
+
+// registryChange is a game of this server which was added or removed
+type registryChange struct {
+	id    string
+	added bool
+}
+
+// storedListing is a listed game in the store
+type storedListing struct {
+	GameListing
+	Node string `json:"node"`
+	// when the game was created and the listing last published,
+	// in milliseconds since the epoch
+	Created int64 `json:"created"`
+	Updated int64 `json:"updated"`
+}
+
+func newSharedRegistry(cl *Cluster) *sharedRegistry {
+	return &sharedRegistry{gameRegistry: newGameRegistry(), cluster: cl, changes: make(chan registryChange, sharedChanges)}
+}
+
+// Add implements GameRegistry
+func (r *sharedRegistry) Add(g *Game) {
+	r.gameRegistry.Add(g)
+	r.change(registryChange{id: g.id, added: true})
+}
+
+// Remove implements GameRegistry
+func (r *sharedRegistry) Remove(id string) {
+	r.gameRegistry.Remove(id)
+	r.change(registryChange{id: id})
+}
+
+// change passes the change to share without waiting for the store,
+// changes are dropped while the store is too far behind
+func (r *sharedRegistry) change(c registryChange) {
+	select {
+	case r.changes <- c:
+	default:
+		log.Printf("Dropping the change of game %s, the store is too far behind", c.id)
+	}
+}
+
+// share writes the changes of the games of this server to the store
+func (r *sharedRegistry) share() {
+	for c := range r.changes {
+		if c.added {
+			if err := r.cluster.store.HSet(gamesKey, c.id, r.cluster.self.ID); err != nil {
+				log.Printf("Could not share game %s, %v", c.id, err)
+			}
+			continue
+		}
+		if err := r.cluster.store.HDel(gamesKey, c.id); err != nil {
+			log.Printf("Could not remove game %s, %v", c.id, err)
+		}
+		if err := r.cluster.store.HDel(listedKey, c.id); err != nil {
+			log.Printf("Could not remove the listing of game %s, %v", c.id, err)
+		}
+	}
+}
+
+// List implements GameRegistry, the listing is published right away
+func (r *sharedRegistry) List(g *Game) {
+	r.gameRegistry.List(g)
+	r.publish(time.Now())
+}
+
+// Open implements GameRegistry, the games of this server are up to date
+// and those of the others as of their last announcement
+func (r *sharedRegistry) Open(filter gameFilter, now time.Time) []GameListing {
+	listings := r.listings(now)
+	stored, err := r.cluster.store.HGetAll(listedKey)
+	if err != nil {
+		log.Printf("Could not get the games of the other servers, %v", err)
+		return filterListings(listings, filter)
+	}
+	updatedSince := unixMillis(now.Add(-nodeTimeout))
+	for id, data := range stored {
+		var s storedListing
+		if err := json.Unmarshal([]byte(data), &s); err != nil || s.Node == r.cluster.self.ID {
+			continue
+		}
+		if s.Updated < updatedSince {
+			// the server is gone, or it missed the removal of the game
+			r.cluster.store.HDel(listedKey, id)
+			continue
+		}
+		s.createdAt = time.Unix(0, s.Created*int64(time.Millisecond))
+		s.Age = int(now.Sub(s.createdAt).Seconds())
+		listings = append(listings, s.GameListing)
+	}
+	return filterListings(listings, filter)
+}
+
+// Host implements GameRegistry
+func (r *sharedRegistry) Host(id string) (string, bool) {
+	node, err := r.cluster.store.HGet(gamesKey, id)
+	if err != nil || node == r.cluster.self.ID {
+		return "", false
+	}
+	nodes, err := r.cluster.nodes()
+	if err != nil {
+		return "", false
+	}
+	host, ok := nodes[node]
+	return host.URL, ok
+}
+
+// publish shares the listings of the games of this server
+func (r *sharedRegistry) publish(now time.Time) {
+	for _, listing := range r.listings(now) {
+		data, err := json.Marshal(storedListing{
+			GameListing: listing,
+			Node:        r.cluster.self.ID,
+			Created:     unixMillis(listing.createdAt),
+			Updated:     unixMillis(now),
+		})
+		if err != nil {
+			continue
+		}
+		if err := r.cluster.store.HSet(listedKey, listing.ID, string(data)); err != nil {
+			log.Printf("Could not publish game %s, %v", listing.ID, err)
+			return
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/lazareviczoran/blaster-twister/store"
+	"github.com/lazareviczoran/blaster-twister/store/storetest"
+)
+
+func TestSelectHost(t *testing.T) {
+	nodes := map[string]clusterNode{
+		"a": {ID: "a", Region: "eu"},
+		"b": {ID: "b", Region: "eu"},
+		"c": {ID: "c", Region: "us"},
+	}
+	fallback := clusterNode{ID: "self"}
+	tests := []struct {
+		name    string
+		players []matchPlayer
+		want    string
+	}{
+		{"no players", nil, "self"},
+		{"players of a single server", []matchPlayer{{"c", 50 * time.Millisecond}, {"c", 80 * time.Millisecond}}, "c"},
+		{"server of more players", []matchPlayer{{"a", 20 * time.Millisecond}, {"a", 20 * time.Millisecond}, {"b", 20 * time.Millisecond}}, "a"},
+		{"tie goes to the lower id", []matchPlayer{{"b", 20 * time.Millisecond}, {"a", 20 * time.Millisecond}}, "a"},
+		{"region of more players", []matchPlayer{{"a", 20 * time.Millisecond}, {"c", 20 * time.Millisecond}, {"c", 20 * time.Millisecond}}, "c"},
+		{"server which is gone", []matchPlayer{{"gone", 20 * time.Millisecond}}, "self"},
+	}
+	for _, test := range tests {
+		if got := selectHost(nodes, test.players, fallback); got.ID != test.want {
+			t.Errorf("%s: selected %s, want %s", test.name, got.ID, test.want)
+		}
+	}
+}
+
+// serveMail handles the next message in the mailbox of the server like Cluster.receive
+func serveMail(t *testing.T, cl *Cluster) {
+	t.Helper()
+	mailbox := store.NewClient(cl.addr)
+	defer mailbox.Close()
+	data, err := mailbox.BLPop(mailboxPrefix+cl.self.ID, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var msg nodeMessage
+	if err := json.Unmarshal([]byte(data), &msg); err != nil {
+		t.Fatal(err)
+	}
+	switch msg.Kind {
+	case "host":
+		cl.host(*msg.Game, msg.ReplyTo)
+	case "hosted":
+		cl.hosted(msg.Key, msg.Error)
+	default:
+		t.Fatalf("Unexpected %s message", msg.Kind)
+	}
+}
+
+func TestHostGameOnAnotherServer(t *testing.T) {
+	s, err := storetest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	a := newCluster(s.Addr(), clusterNode{ID: "a", URL: "http://a", Region: "eu"})
+	b := newCluster(s.Addr(), clusterNode{ID: "b", URL: "http://b", Region: "us"})
+	for _, cl := range []*Cluster{a, b} {
+		if err := cl.announce(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		capacity int
+		players  []matchPlayer
+		rated    bool
+	}{
+		{"players of the hosting server", 2, []matchPlayer{{"b", 20 * time.Millisecond}, {"b", 30 * time.Millisecond}}, true},
+		{"players of several servers", 3, []matchPlayer{{"b", 20 * time.Millisecond}, {"b", 30 * time.Millisecond}, {"a", 20 * time.Millisecond}}, false},
+		{"game which can't be created", maxPlayers + 1, []matchPlayer{{"b", 20 * time.Millisecond}}, false},
+	}
+	for _, test := range tests {
+		spec := lobbyGame{ID: randToken(), Capacity: test.capacity, Rated: true}
+		type result struct {
+			host string
+			err  error
+		}
+		hosted := make(chan result, 1)
+		go func() {
+			host, err := a.hostGame(spec, test.players)
+			hosted <- result{host, err}
+		}()
+		serveMail(t, b)
+		serveMail(t, a)
+		res := <-hosted
+
+		game := activeGames.Get(spec.ID)
+		if test.capacity > maxPlayers {
+			if res.err == nil || game != nil {
+				t.Errorf("%s: got host %q, want an error", test.name, res.host)
+			}
+			continue
+		}
+		if res.err != nil || res.host != "http://b" || game == nil {
+			t.Errorf("%s: got host %q, %v, want the game on server b", test.name, res.host, res.err)
+			continue
+		}
+		if game.rated != test.rated {
+			t.Errorf("%s: got a rated game %v, want %v", test.name, game.rated, test.rated)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"crypto/subtle"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/lazareviczoran/blaster-twister/protocol"
+)
+
+// debugHub streams the bot decisions of a game to the subscribed clients
+type debugHub struct {
+	mu      sync.Mutex
+	enabled bool
+	clients map[*debugClient]bool
+}
+
+type debugClient struct {
+	conn *websocket.Conn
+	send chan []byte
+}
+
+func newDebugHub() *debugHub {
+	return &debugHub{clients: make(map[*debugClient]bool)}
+}
+
+// allowed checks if the request may subscribe to the debug stream,
+// which is possible for games that opted in or with the admin token
+func (d *debugHub) allowed(r *http.Request) bool {
+	d.mu.Lock()
+	enabled := d.enabled
+	d.mu.Unlock()
+	adminToken := os.Getenv("ADMIN_TOKEN")
+	token := r.URL.Query().Get("token")
+	return enabled || (adminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1)
+}
+
+func (d *debugHub) enable() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.enabled = true
+}
+
+func (d *debugHub) subscribe(conn *websocket.Conn) {
+	c := &debugClient{conn, make(chan []byte, 256)}
+	d.mu.Lock()
+	d.clients[c] = true
+	d.mu.Unlock()
+	go c.writePump()
+	go d.readPump(c)
+}
+
+func (d *debugHub) unsubscribe(c *debugClient) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.clients[c] {
+		delete(d.clients, c)
+		close(c.send)
+	}
+}
+
+// publish sends the decision to all subscribers, the clients which
+// can't keep up miss the decision
+func (d *debugHub) publish(decision *protocol.BotDecision) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.clients) == 0 {
+		return
+	}
+	res, err := protocol.Encode(protocol.Debug{Debug: *decision})
+	if err != nil {
+		log.Printf("Could not encode debug message, %v", err)
+		return
+	}
+	for c := range d.clients {
+		select {
+		case c.send <- res:
+		default:
+		}
+	}
+}
+
+// close disconnects all subscribers
+func (d *debugHub) close() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for c := range d.clients {
+		delete(d.clients, c)
+		close(c.send)
+	}
+}
+
+// readPump only waits for the connection to close, the subscribers
+// don't send anything
+func (d *debugHub) readPump(c *debugClient) {
+	defer d.unsubscribe(c)
+	c.conn.SetReadLimit(maxMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error { c.conn.SetReadDeadline(time.Now().Add(pongWait)); return nil })
+	for {
+		if _, _, err := c.conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+func (c *debugClient) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+	for {
+		select {
+		case message, ok := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if !ok {
+				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+)
+
+const (
+	// Name of the cookie which identifies the players session
+	sessionCookie = "bt_session"
+
+	// Number of recent results remembered per session
+	recentResults = 10
+
+	// Change of the difficulty level after each result
+	adaptStep = 0.1
+
+	// Difficulty level of players without any results
+	initialLevel = 0.5
+
+	// Look ahead of the easiest and the hardest bots, the hardest ones see
+	// across the whole board, whose diagonal is about 781 pixels long
+	minLookAhead     = 60
+	maxLookAhead     = 800
+	maxReactionDelay = 8
+	maxMistakeRate   = 0.25
+)
+
+// Difficulty contains the parameters which make a bot stronger or weaker
+type Difficulty struct {
+	// Maximal distance in pixels the bot considers when looking for walls
+	LookAhead int
+	// Number of ticks before the bot reacts to a decision
+	ReactionDelay int
+	// Probability of steering in a wrong direction
+	MistakeRate float64
+}
+
+var maxDifficulty = difficultyForLevel(1)
+
+// difficultyForLevel maps a level between 0 (easiest) and 1 (hardest)
+// to the bots parameters
+func difficultyForLevel(level float64) Difficulty {
+	return Difficulty{
+		LookAhead:     minLookAhead + int(level*(maxLookAhead-minLookAhead)),
+		ReactionDelay: int((1 - level) * maxReactionDelay),
+		MistakeRate:   (1 - level) * maxMistakeRate,
+	}
+}
+
+type skillRecord struct {
+	level   float64
+	results []bool
+}
+
+// skillTracker remembers the recent single player results of each session
+// and adapts the difficulty, so that each player wins about half of the games
+type skillTracker struct {
+	mu       sync.Mutex
+	sessions map[string]*skillRecord
+}
+
+var skills = &skillTracker{sessions: make(map[string]*skillRecord)}
+
+func (t *skillTracker) get(session string) *skillRecord {
+	record := t.sessions[session]
+	if record == nil {
+		record = &skillRecord{level: initialLevel}
+		t.sessions[session] = record
+	}
+	return record
+}
+
+// difficulty returns the bot difficulty for the given session
+func (t *skillTracker) difficulty(session string) Difficulty {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return difficultyForLevel(t.get(session).level)
+}
+
+// record stores the result of a game and adjusts the level, a win makes
+// the next bots harder, a loss makes them easier
+func (t *skillTracker) record(session string, won bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	record := t.get(session)
+	record.results = append(record.results, won)
+	if len(record.results) > recentResults {
+		record.results = record.results[1:]
+	}
+	wins := 0
+	for _, result := range record.results {
+		if result {
+			wins++
+		}
+	}
+
+	oldLevel := record.level
+	if won {
+		record.level += adaptStep
+	} else {
+		record.level -= adaptStep
+	}
+	if record.level > 1 {
+		record.level = 1
+	} else if record.level < 0 {
+		record.level = 0
+	}
+	d := difficultyForLevel(record.level)
+	log.Printf("Bot difficulty for session %s: level %.2f -> %.2f (won %d of the last %d games), look-ahead %d, reaction delay %d, mistake rate %.2f",
+		sessionLabel(session), oldLevel, record.level, wins, len(record.results), d.LookAhead, d.ReactionDelay, d.MistakeRate)
+}
+
+// sessionID returns the session of the request, a new session
+// is created if the request doesn't have one
+func sessionID(w http.ResponseWriter, r *http.Request) string {
+	if cookie, err := r.Cookie(sessionCookie); err == nil && cookie.Value != "" {
+		return cookie.Value
+	}
+	session := newSessionID()
+	http.SetCookie(w, &http.Cookie{Name: sessionCookie, Value: session, Path: "/", HttpOnly: true})
+	return session
+}
+
+// newSessionID returns an unpredictable session id, the sessions
+// carry the ratings and profiles and authorize the hosts of rooms
+func newSessionID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		log.Fatalf("Could not generate a session id, %v", err)
+	}
+	return fmt.Sprintf("%x", b)
+}
+
+// sessionLabel identifies the session in the logs by a short hash,
+// as the session id itself authenticates the player
+func sessionLabel(session string) string {
+	sum := sha256.Sum256([]byte(session))
+	return fmt.Sprintf("%x", sum[:4])
+}
+
+// requestSession returns the session of the request, if it has one
+func requestSession(r *http.Request) string {
+	if cookie, err := r.Cookie(sessionCookie); err == nil {
+		return cookie.Value
+	}
+	return ""
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDifficultyForLevel(t *testing.T) {
+	diagonal := int(math.Ceil(math.Hypot(float64(width), float64(height))))
+	if maxDifficulty.LookAhead < diagonal {
+		t.Errorf("The hardest bots look %d pixels ahead, less than the diagonal of %d", maxDifficulty.LookAhead, diagonal)
+	}
+	if maxDifficulty.ReactionDelay != 0 || maxDifficulty.MistakeRate != 0 {
+		t.Errorf("Got %+v, want the hardest bots without delays and mistakes", maxDifficulty)
+	}
+	easiest := difficultyForLevel(0)
+	if easiest.LookAhead != minLookAhead || easiest.ReactionDelay != maxReactionDelay || easiest.MistakeRate != maxMistakeRate {
+		t.Errorf("Got %+v for the easiest bots", easiest)
+	}
+}
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"time"
+)
+
+const (
+	// Number of rays cast around each player for the observation.
+	envRays = 36
+
+	rewardWin   = 1.0
+	rewardDeath = -1.0
+)
+
+// Action is the steering input of a single player for one step
+type Action int
+
+// Available actions
+const (
+	ActionLeft     Action = -1
+	ActionStraight Action = 0
+	ActionRight    Action = 1
+)
+
+// EnvPlayer is the observed state of a single player
+type EnvPlayer struct {
+	ID       int  `json:"id"`
+	X        int  `json:"x"`
+	Y        int  `json:"y"`
+	Rotation int  `json:"rotation"`
+	Trace    bool `json:"trace"`
+	Alive    bool `json:"alive"`
+	// Distances to the nearest obstacle, starting with the heading
+	// and going clockwise in 10 degree steps
+	Rays []int `json:"rays"`
+}
+
+// Observation is the state of the environment after a reset or a step
+type Observation struct {
+	Tick    int         `json:"tick"`
+	Width   int         `json:"width"`
+	Height  int         `json:"height"`
+	Players []EnvPlayer `json:"players"`
+}
+
+type envAgent struct {
+	owner    *Bot
+	x        int
+	y        int
+	rotation int
+	// action of the last step and for how long it was held since the
+	// last turn, the player turns like with the rotation ticker
+	turn        Action
+	turning     time.Duration
+	trace       bool
+	alive       bool
+	traceToggle int
+}
+
+// Env is a synchronous version of the game rules that can be stepped
+// tick by tick, without timers, goroutines or websockets.
+// It is meant to be used for training bots.
+type Env struct {
+	board   *Board
+	agents  []*envAgent
+	rand    *rand.Rand
+	tick    int
+	done    bool
+	players int
+}
+
+// NewEnv creates an environment for the given number of players
+func NewEnv(players int) (*Env, error) {
+	if players < 1 || players > maxPlayers {
+		return nil, fmt.Errorf("unsupported number of players %d", players)
+	}
+	return &Env{players: players}, nil
+}
+
+// Reset starts a new round and returns the initial observation,
+// the same seed always produces the same round
+func (e *Env) Reset(seed int64) Observation {
+	e.rand = rand.New(rand.NewSource(seed))
+	e.board = initBoard(height, width)
+	e.agents = make([]*envAgent, e.players)
+	e.tick = 0
+	e.done = false
+	for id := range e.agents {
+		x, y := startPosition(id, e.players)
+		a := &envAgent{
+			owner:    &Bot{PlayerData: PlayerData{id: id, alive: true}, difficulty: maxDifficulty},
+			x:        x,
+			y:        y,
+			rotation: e.rand.Intn(90),
+			alive:    true,
+		}
+		a.traceToggle = e.nextTraceToggle()
+		e.board.fields[x][y].setUsed(a.owner)
+		e.agents[id] = a
+	}
+	return e.observe()
+}
+
+// Step applies one action per player, advances the game by one tick
+// and returns the new observation, the reward of each player and
+// whether the round is over
+func (e *Env) Step(actions []Action) (Observation, []float64, bool, error) {
+	if e.board == nil {
+		return Observation{}, nil, false, errors.New("Reset has to be called before Step")
+	}
+	if e.done {
+		return Observation{}, nil, true, errors.New("the round is over, call Reset to start a new one")
+	}
+	if len(actions) != len(e.agents) {
+		return Observation{}, nil, false, fmt.Errorf("expected %d actions, got %d", len(e.agents), len(actions))
+	}
+
+	rewards := make([]float64, len(e.agents))
+	e.tick++
+	for id, a := range e.agents {
+		if !a.alive {
+			continue
+		}
+		a.steer(actions[id])
+		if e.tick >= a.traceToggle {
+			a.trace = !a.trace
+			a.traceToggle = e.tick + e.nextTraceToggle()
+		}
+		if !e.move(a) {
+			a.alive = false
+			rewards[id] += rewardDeath
+		}
+	}
+
+	alive := 0
+	for _, a := range e.agents {
+		if a.alive {
+			alive++
+		}
+	}
+	if alive == 0 || (len(e.agents) > 1 && alive == 1) {
+		e.done = true
+		for id, a := range e.agents {
+			if a.alive {
+				rewards[id] += rewardWin
+			}
+		}
+	}
+	return e.observe(), rewards, e.done, nil
+}
+
+// steer turns the agent for one tick, holding a direction turns it by
+// rotationStep every rotationInterval, like the rotation ticker does
+func (a *envAgent) steer(action Action) {
+	if action != a.turn {
+		// the ticker starts again when the direction changes
+		a.turn, a.turning = action, 0
+	}
+	if action == ActionStraight {
+		return
+	}
+	for a.turning += time.Second / fps; a.turning >= rotationInterval; a.turning -= rotationInterval {
+		if action < ActionStraight {
+			a.rotation = (a.rotation + 360 - rotationStep) % 360
+		} else {
+			a.rotation = (a.rotation + rotationStep) % 360
+		}
+	}
+}
+
+// move advances the agent by the move of a player in the game,
+// it returns false if the agent crashed
+func (e *Env) move(a *envAgent) bool {
+	return e.board.move(a.owner, a.x, a.y, float64(a.rotation)*math.Pi/180, a.trace, func(x, y int) {
+		a.x, a.y = x, y
+	})
+}
+
+// nextTraceToggle returns the number of ticks until the trace is switched,
+// matches the 1-2 seconds of the visited ticker
+func (e *Env) nextTraceToggle() int {
+	return (1000 + e.rand.Intn(1000)) / (1000 / fps)
+}
+
+func (e *Env) observe() Observation {
+	obs := Observation{Tick: e.tick, Width: width, Height: height}
+	for id, a := range e.agents {
+		rays := make([]int, envRays)
+		for i := range rays {
+			rays[i] = e.board.distanceToWall(a.x, a.y, (a.rotation+i*360/envRays)%360)
+		}
+		obs.Players = append(obs.Players, EnvPlayer{id, a.x, a.y, a.rotation, a.trace, a.alive, rays})
+	}
+	return obs
+}
+
+type envRequest struct {
+	Cmd     string   `json:"cmd"`
+	Seed    int64    `json:"seed"`
+	Players int      `json:"players"`
+	Actions []Action `json:"actions"`
+}
+
+type envResponse struct {
+	Observation *Observation `json:"observation,omitempty"`
+	Rewards     []float64    `json:"rewards,omitempty"`
+	Done        bool         `json:"done"`
+	Error       string       `json:"error,omitempty"`
+}
+
+// serveEnv drives an Env with newline delimited JSON commands, e.g.
+//
+//	{"cmd": "reset", "seed": 42, "players": 2}
+//	{"cmd": "step", "actions": [-1, 0]}
+//
+// and writes one JSON response per command
+func serveEnv(r io.Reader, w io.Writer) error {
+	var env *Env
+	scanner := bufio.NewScanner(r)
+	encoder := json.NewEncoder(w)
+	for scanner.Scan() {
+		var req envRequest
+		var res envResponse
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			res.Error = err.Error()
+		} else {
+			switch req.Cmd {
+			case "reset":
+				if req.Players == 0 {
+					req.Players = 2
+				}
+				var err error
+				if env, err = NewEnv(req.Players); err != nil {
+					res.Error = err.Error()
+					break
+				}
+				obs := env.Reset(req.Seed)
+				res.Observation = &obs
+			case "step":
+				if env == nil {
+					res.Error = "reset has to be sent before step"
+					break
+				}
+				obs, rewards, done, err := env.Step(req.Actions)
+				if err != nil {
+					res.Error = err.Error()
+					res.Done = done
+					break
+				}
+				res.Observation = &obs
+				res.Rewards = rewards
+				res.Done = done
+			default:
+				res.Error = fmt.Sprintf("unknown command %q", req.Cmd)
+			}
+		}
+		if err := encoder.Encode(&res); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
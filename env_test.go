@@ -0,0 +1,167 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"testing"
+)
+
+// stepUntilDone steps the environment with the same action for every
+// player until the round is over, it returns the rewards of the last step
+func stepUntilDone(t *testing.T, e *Env, action Action) []float64 {
+	t.Helper()
+	actions := make([]Action, e.players)
+	for i := range actions {
+		actions[i] = action
+	}
+	for i := 0; i < width+height; i++ {
+		_, rewards, done, err := e.Step(actions)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if done {
+			return rewards
+		}
+	}
+	t.Fatal("The round didn't end")
+	return nil
+}
+
+func TestEnvReset(t *testing.T) {
+	e, err := NewEnv(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	obs := e.Reset(42)
+	if len(obs.Players) != 3 || obs.Tick != 0 || obs.Width != width || obs.Height != height {
+		t.Fatalf("Got %+v, want 3 players at tick 0", obs)
+	}
+	for id, p := range obs.Players {
+		if x, y := startPosition(id, 3); p.ID != id || !p.Alive || p.X != x || p.Y != y || len(p.Rays) != envRays {
+			t.Errorf("Got player %+v, want it alive at %d,%d", p, x, y)
+		}
+	}
+	if again := e.Reset(42); !reflect.DeepEqual(obs, again) {
+		t.Errorf("The same seed started another round, got %+v and %+v", obs, again)
+	}
+	if _, err := NewEnv(maxPlayers + 1); err == nil {
+		t.Errorf("Created an environment for %d players", maxPlayers+1)
+	}
+}
+
+func TestEnvStepBeforeReset(t *testing.T) {
+	e, err := NewEnv(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := e.Step([]Action{ActionLeft, ActionRight}); err == nil {
+		t.Error("Stepped before the reset")
+	}
+	e.Reset(1)
+	if _, _, _, err := e.Step([]Action{ActionLeft}); err == nil {
+		t.Error("Stepped with an action missing")
+	}
+}
+
+func TestEnvCrashOnWall(t *testing.T) {
+	e, err := NewEnv(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Reset(7)
+	rewards := stepUntilDone(t, e, ActionStraight)
+	if !reflect.DeepEqual(rewards, []float64{rewardDeath}) {
+		t.Errorf("Got rewards %v for crashing into the wall", rewards)
+	}
+	if _, _, done, err := e.Step([]Action{ActionStraight}); err == nil || !done {
+		t.Error("Stepped after the round was over")
+	}
+}
+
+func TestEnvCrashOnTraceRewardsWinner(t *testing.T) {
+	e, err := NewEnv(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Reset(7)
+	// the first player is surrounded by the trace of the second one
+	a, other := e.agents[0], e.agents[1].owner
+	for d := -5; d <= 5; d++ {
+		for _, offset := range []int{-5, 5} {
+			e.board.fields[a.x+d][a.y+offset].setUsed(other)
+			e.board.fields[a.x+offset][a.y+d].setUsed(other)
+		}
+	}
+	rewards := stepUntilDone(t, e, ActionStraight)
+	if !reflect.DeepEqual(rewards, []float64{rewardDeath, rewardWin}) {
+		t.Errorf("Got rewards %v, want the first player crashed and the second one winning", rewards)
+	}
+	if obs := e.observe(); obs.Players[0].Alive || !obs.Players[1].Alive || obs.Tick > 3 {
+		t.Errorf("Got %+v, want the first player crashed into the trace right away", obs)
+	}
+}
+
+func TestEnvTurnsLikeTheGame(t *testing.T) {
+	a := &envAgent{}
+	// the rotation ticker turns the player 5 times every 3 ticks
+	for _, want := range []int{5, 15, 25, 30, 40, 50} {
+		a.steer(ActionRight)
+		if a.rotation != want {
+			t.Fatalf("Turned to %d, want %d", a.rotation, want)
+		}
+	}
+	a.steer(ActionLeft)
+	if a.rotation != 45 {
+		t.Errorf("Turned to %d, want the ticker to start again with the other direction", a.rotation)
+	}
+}
+
+func TestServeEnv(t *testing.T) {
+	commands, input := io.Pipe()
+	output, responses := io.Pipe()
+	served := make(chan error, 1)
+	go func() {
+		served <- serveEnv(commands, responses)
+	}()
+	decoder := json.NewDecoder(output)
+	send := func(command string) envResponse {
+		t.Helper()
+		if _, err := io.WriteString(input, command+"\n"); err != nil {
+			t.Fatal(err)
+		}
+		var res envResponse
+		if err := decoder.Decode(&res); err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+
+	if res := send(`{"cmd": "step", "actions": [0]}`); res.Error == "" {
+		t.Errorf("Got %+v, want an error for stepping before the reset", res)
+	}
+	res := send(`{"cmd": "reset", "seed": 7, "players": 1}`)
+	if res.Error != "" || res.Observation == nil || len(res.Observation.Players) != 1 || res.Done {
+		t.Fatalf("Got %+v, want the observation of a new round", res)
+	}
+	for steps := 0; !res.Done; steps++ {
+		if steps > width+height {
+			t.Fatal("The round didn't end")
+		}
+		res = send(`{"cmd": "step", "actions": [0]}`)
+		if res.Error != "" || res.Observation == nil || len(res.Rewards) != 1 {
+			t.Fatalf("Got %+v, want the observation and the rewards of the step", res)
+		}
+	}
+	if res.Rewards[0] != rewardDeath || res.Observation.Players[0].Alive {
+		t.Errorf("Got %+v, want the player crashed into the wall", res)
+	}
+	if res := send(`{"cmd": "jump"}`); res.Error == "" {
+		t.Errorf("Got %+v, want an error for an unknown command", res)
+	}
+
+	input.Close()
+	if err := <-served; err != nil {
+		t.Error(err)
+	}
+}
@@ -10,21 +10,54 @@ const LEFT_KEY = 'ArrowLeft';
 const RIGHT_KEY = 'ArrowRight';
 const WEBSOCKET_PROTOCOL = window.location.hostname === 'localhost' ? 'ws' : 'wss';
 const WEBSOCKET_BASE_URL = `${WEBSOCKET_PROTOCOL}://${window.location.host}/ws/game`;
+const REMATCH_URL = `${WEBSOCKET_PROTOCOL}://${window.location.host}/ws/rematch`;
+const COLORS = ['green', 'red', 'yellow', 'cyan'];
+const PROTOCOL_VERSION = 5;
+const SUBPROTOCOLS = ['blaster-twister.binary', 'blaster-twister.json'];
+const PERSONALITIES = ['', 'standard', 'aggressive', 'cautious', 'erratic'];
+const RECONNECT_DELAY = 1000;
+// degrees per millisecond, the server turns by 5 degrees every 30ms
+const ROTATION_SPEED = 5 / 30;
 const {
-  Point, PointText, Path, Raster, Layer,
+  Point, PointText, Path, Raster, Layer, Group,
 } = Paper;
 
 const playerPos = {};
 const currentPaths = {};
 const gameId = window.location.pathname.substring(3);
-const clientId = new Date().getTime();
 let playerId;
 let textItem;
 let pathLayer;
 let iconLayer;
 let messageLayer;
-let mainWs;
-let cmdWs;
+let debugLayer;
+const debugGroups = {};
+let ws;
+let lastTick = 0;
+let gameOver = false;
+const resumeKey = `resume-${gameId}`;
+// token of the seat reserved by a private room or a rematch
+const seatKey = `seat-${gameId}`;
+let seatToken;
+let rematchOffer;
+// inputs sent to the server which it hasn't processed yet
+let pendingInputs = [];
+let inputSeq = 0;
+// difference of the local clock to the server clock in milliseconds
+let clockOffset = 0;
+let countdownTimeout;
+// profiles of the players by their ids, from the players message
+const profiles = {};
+
+const playerName = (pId) => {
+  const profile = profiles[pId] || {};
+  if (profile.nickname) {
+    return profile.nickname;
+  }
+  return `Player ${parseInt(pId, 10) + 1}`;
+};
+const playerColor = (pId) => (profiles[pId] || {}).color || COLORS[parseInt(pId, 10) % COLORS.length];
+const playerSkin = (pId) => (profiles[pId] || {}).skin || `rocket${(parseInt(pId, 10) % 2) + 1}`;
 
 const createOrMoveTriangle = (pId, { x, y, rotation }) => {
   const playerTriangle = playerPos[pId];
@@ -32,8 +65,7 @@ const createOrMoveTriangle = (pId, { x, y, rotation }) => {
     playerTriangle.position = new Point(x, y);
     playerTriangle.rotation = rotation + 90;
   } else {
-    const icon = pId === '0' ? 'rocket1' : 'rocket2';
-    const playerIcon = new Raster(icon);
+    const playerIcon = new Raster(playerSkin(pId));
     playerIcon.position = new Point(x, y);
     playerIcon.rotation = rotation + 90;
     playerIcon.scale(0.15);
@@ -41,6 +73,25 @@ const createOrMoveTriangle = (pId, { x, y, rotation }) => {
     playerPos[pId] = playerIcon;
   }
 };
+// predictRotation applies the inputs the server hasn't processed yet
+// to the rotation of the own player from the snapshot
+const predictRotation = ({ rotation, rotationDir, lastInput = 0 }) => {
+  pendingInputs = pendingInputs.filter((input) => input.seq > lastInput);
+  let predicted = rotation;
+  let turning = rotationDir;
+  pendingInputs.forEach((input, i) => {
+    if (input.dir === DOWN) {
+      turning = input.key;
+    } else if (input.key === turning) {
+      turning = undefined;
+    }
+    const end = i + 1 < pendingInputs.length ? pendingInputs[i + 1].time : Date.now();
+    if (turning) {
+      predicted += (turning === RIGHT ? 1 : -1) * ROTATION_SPEED * (end - input.time);
+    }
+  });
+  return Math.round(((predicted % 360) + 360) % 360);
+};
 const markFieldAsUsed = (pId, { x, y, trace }) => {
   const playerPath = currentPaths[pId];
   if (trace) {
@@ -48,7 +99,7 @@ const markFieldAsUsed = (pId, { x, y, trace }) => {
       playerPath.add(new Point(x, y));
     } else {
       const path = new Path();
-      path.strokeColor = pId === '0' ? 'green' : 'red';
+      path.strokeColor = playerColor(pId);
       path.strokeWidth = 2;
       path.add(new Point(x, y));
       pathLayer.addChild(path);
@@ -65,6 +116,54 @@ const movePlayers = (players) => {
     createOrMoveTriangle(id, p);
   });
 };
+const getPlayerSpan = (pId) => {
+  let playerSpan = document.getElementById(`player${pId}`);
+  if (!playerSpan) {
+    const row = document.createElement('div');
+    row.id = `row${pId}`;
+    row.className = 'col-12 mb-2 mt-2';
+    row.style.color = playerColor(pId);
+    row.innerHTML = `<img id="skin${pId}" src="/img/${playerSkin(pId)}.png" width="30px" />
+      <span id="name${pId}"></span> (<span id="player${pId}"></span>)<span id="status${pId}"></span>
+      <small id="ping${pId}"></small>`;
+    document.getElementById('players').appendChild(row);
+    document.getElementById(`name${pId}`).textContent = playerName(pId);
+    playerSpan = document.getElementById(`player${pId}`);
+  }
+  return playerSpan;
+};
+// updateProfiles shows the nicknames, colors and rockets of the players
+const updateProfiles = (players) => {
+  players.forEach((player) => {
+    profiles[player.id] = player;
+    const playerSpan = getPlayerSpan(player.id);
+    document.getElementById(`row${player.id}`).style.color = playerColor(player.id);
+    document.getElementById(`skin${player.id}`).src = `/img/${playerSkin(player.id)}.png`;
+    document.getElementById(`name${player.id}`).textContent = playerName(player.id);
+    if (player.bot) {
+      playerSpan.innerHTML = `Bot, ${player.personality}`;
+    }
+    if (currentPaths[player.id]) {
+      currentPaths[player.id].strokeColor = playerColor(player.id);
+    }
+  });
+};
+// showPlayerEvent marks the disconnected and eliminated players
+const showPlayerEvent = ({ playerId: pId, event, cause }) => {
+  getPlayerSpan(pId);
+  const statusSpan = document.getElementById(`status${pId}`);
+  if (event === 'eliminated') {
+    statusSpan.innerHTML = ` ${cause}`;
+    const entry = document.createElement('li');
+    entry.style.color = playerColor(pId);
+    entry.textContent = `${playerName(pId)} ${cause === 'crashed' ? 'crashed' : 'was disconnected'}`;
+    document.getElementById('feed').appendChild(entry);
+  } else if (event === 'disconnected') {
+    statusSpan.innerHTML = ' disconnected';
+  } else if (statusSpan.innerHTML === ' disconnected') {
+    statusSpan.innerHTML = '';
+  }
+};
 const createMessage = (content) => {
   const text = new PointText(new Point(0, 0));
   text.visible = false;
@@ -79,31 +178,197 @@ const createMessage = (content) => {
     fontSize: 20,
   });
 };
-const drawWinner = (winnerId, actualPlayerId) => {
+const drawWinner = (winnerId, actualPlayerId, forfeited = []) => {
   let content;
   if (winnerId === actualPlayerId) {
     content = 'You won!! :)';
   } else {
-    content = `Player ${winnerId + 1} won!`;
+    content = `${playerName(winnerId)} won!`;
+  }
+  if (forfeited.length > 0) {
+    content += `\n${forfeited.map(playerName).join(', ')} forfeited`;
+  }
+  if (rematchOffer && rematchOffer.games > 1) {
+    const wins = rematchOffer.wins.map((w, id) => `${id === actualPlayerId ? 'You' : playerName(id)} ${w}`);
+    content += `\nSeries: ${wins.join(' - ')}`;
   }
   const messageItem = createMessage(content);
   messageLayer.addChild(messageItem);
   document.getElementById('back').classList.remove('d-none');
+  if (rematchOffer) {
+    document.getElementById('rematch').classList.remove('d-none');
+  }
 };
 
-const openCmdWs = (myId) => {
-  cmdWs = new WebSocket(`${WEBSOCKET_BASE_URL}/${gameId}/${clientId}/${myId}`);
-  cmdWs.onopen = () => {};
-  cmdWs.onclose = () => {
-    cmdWs = null;
+// acceptRematch accepts the rematch offer and moves to the new game
+// once all players accepted
+const acceptRematch = () => {
+  const button = document.getElementById('rematch');
+  const status = document.getElementById('rematch-status');
+  button.classList.add('disabled');
+  const socket = new WebSocket(`${REMATCH_URL}/${gameId}?token=${encodeURIComponent(seatToken)}`);
+  socket.onopen = () => {
+    socket.send(JSON.stringify({ type: 'rematch', accept: true }));
+  };
+  socket.onmessage = (evt) => {
+    const msg = JSON.parse(evt.data);
+    switch (msg.type) {
+      case 'rematchOffer':
+        status.innerHTML = `Waiting for the other players to accept (${(msg.accepted || []).length} accepted)`;
+        break;
+      case 'start':
+        sessionStorage.setItem(`seat-${msg.game}`, msg.seat);
+        window.location = `/g/${msg.game}`;
+        break;
+      case 'error':
+        status.innerHTML = msg.error;
+        button.classList.add('d-none');
+        break;
+      default:
+    }
   };
-  cmdWs.onmessage = (evt) => {
+  // eslint-disable-next-line no-console
+  socket.onerror = console.error;
+};
+
+// decodeBinary parses the compact messages of the binary subprotocol
+const decodeBinary = (buffer) => {
+  const view = new DataView(buffer);
+  switch (view.getUint8(0)) {
+    case 1: {
+      const players = {};
+      for (let i = 0; i < view.getUint8(1); i += 1) {
+        const offset = 2 + i * 7;
+        const flags = view.getUint8(offset + 6);
+        // eslint-disable-next-line no-bitwise
+        const personality = PERSONALITIES[(flags >> 4) & 7];
+        players[view.getUint8(offset)] = {
+          x: view.getUint16(offset + 1),
+          y: view.getUint16(offset + 3),
+          rotation: Math.round((view.getUint8(offset + 5) * 360) / 256),
+          // eslint-disable-next-line no-bitwise
+          trace: (flags & 1) !== 0,
+          // eslint-disable-next-line no-bitwise
+          bot: (flags & 8) !== 0,
+          personality,
+        };
+      }
+      return { type: 'state', players };
+    }
+    case 4: {
+      const base = view.getUint32(5);
+      const players = {};
+      let offset = 10;
+      for (let i = 0; i < view.getUint8(9); i += 1) {
+        const flags = view.getUint8(offset + 6);
+        const points = [];
+        for (let j = 0; j < view.getUint16(offset + 7); j += 1) {
+          const pointOffset = offset + 9 + j * 6;
+          const x = view.getUint16(pointOffset + 2);
+          points.push([
+            base + view.getUint16(pointOffset),
+            // eslint-disable-next-line no-bitwise
+            x & 0x7fff,
+            view.getUint16(pointOffset + 4),
+            // eslint-disable-next-line no-bitwise
+            x >> 15,
+          ]);
+        }
+        players[view.getUint8(offset)] = {
+          x: view.getUint16(offset + 1),
+          y: view.getUint16(offset + 3),
+          rotation: Math.round((view.getUint8(offset + 5) * 360) / 256),
+          // eslint-disable-next-line no-bitwise
+          trace: (flags & 1) !== 0,
+          // eslint-disable-next-line no-bitwise
+          bot: (flags & 8) !== 0,
+          // eslint-disable-next-line no-bitwise
+          personality: PERSONALITIES[(flags >> 4) & 7],
+          // eslint-disable-next-line no-bitwise
+          alive: (flags & 128) !== 0,
+          // eslint-disable-next-line no-bitwise, no-nested-ternary
+          rotationDir: (flags & 2) ? LEFT : ((flags & 4) ? RIGHT : undefined),
+          points,
+        };
+        offset += 9 + points.length * 6;
+      }
+      if (offset < view.byteLength) {
+        const extras = view.getUint8(offset);
+        for (let i = 0; i < extras; i += 1) {
+          const player = players[view.getUint8(offset + 1 + i * 7)];
+          if (player) {
+            player.lastInput = view.getUint32(offset + 2 + i * 7);
+            player.ping = view.getUint16(offset + 6 + i * 7);
+          }
+        }
+      }
+      return {
+        type: 'snapshot', tick: view.getUint32(1), base, players,
+      };
+    }
+    case 2:
+      return {
+        type: 'countdown',
+        countdown: view.getUint8(1),
+        startAt: view.byteLength === 10
+          ? view.getUint32(2) * 2 ** 32 + view.getUint32(6) : undefined,
+      };
+    case 3:
+      return {
+        type: 'result',
+        winner: view.getUint8(1),
+        forfeited: Array.from(new Uint8Array(buffer, 2)),
+      };
+    default:
+      return { type: 'unknown' };
+  }
+};
+const rayEnd = (x, y, angle, distance) => new Point(
+  x + Math.cos((angle * Math.PI) / 180) * distance,
+  y + Math.sin((angle * Math.PI) / 180) * distance,
+);
+const drawBotDecision = ({
+  bot, x, y, rays, scores, chosen, target, cutOff,
+}) => {
+  if (debugGroups[bot]) {
+    debugGroups[bot].remove();
+  }
+  const group = new Group();
+  const maxScore = Math.max(...scores);
+  const minScore = Math.min(...scores);
+  rays.forEach((distance, i) => {
+    const ray = new Path.Line(new Point(x, y), rayEnd(x, y, i * 10, distance));
+    const relative = maxScore === minScore ? 1 : (scores[i] - minScore) / (maxScore - minScore);
+    ray.strokeColor = `rgba(255, 255, 255, ${0.1 + relative * 0.4})`;
+    ray.strokeWidth = 1;
+    group.addChild(ray);
+  });
+  const chosenLine = new Path.Line(new Point(x, y), rayEnd(x, y, chosen, 60));
+  chosenLine.strokeColor = 'yellow';
+  chosenLine.strokeWidth = 2;
+  group.addChild(chosenLine);
+  const targetLine = new Path.Line(new Point(x, y), rayEnd(x, y, target, 40));
+  targetLine.strokeColor = 'magenta';
+  targetLine.strokeWidth = 2;
+  group.addChild(targetLine);
+  if (cutOff) {
+    const marker = new Path.Circle(new Point(cutOff[0], cutOff[1]), 5);
+    marker.strokeColor = 'orange';
+    group.addChild(marker);
+  }
+  debugLayer.addChild(group);
+  debugGroups[bot] = group;
+};
+const openDebugWs = () => {
+  const debugWs = new WebSocket(`${WEBSOCKET_BASE_URL}/${gameId}/debug${window.location.search}`);
+  debugWs.onmessage = (evt) => {
     const status = JSON.parse(evt.data);
-    // eslint-disable-next-line no-console
-    console.log(status);
+    if (status.debug) {
+      drawBotDecision(status.debug);
+    }
   };
   // eslint-disable-next-line no-console
-  cmdWs.onerror = console.error;
+  debugWs.onerror = console.error;
 };
 
 window.addEventListener('load', () => {
@@ -114,67 +379,185 @@ window.addEventListener('load', () => {
   pathLayer = new Layer();
   iconLayer = new Layer();
   messageLayer = new Layer();
+  debugLayer = new Layer();
 
-  mainWs = new WebSocket(`${WEBSOCKET_BASE_URL}/${gameId}`);
-  mainWs.onopen = () => {
-    mainWs.send(JSON.stringify({ clientId: clientId.toString() }));
-  };
-  mainWs.onclose = () => {
-    mainWs = null;
-  };
-  mainWs.onmessage = (evt) => {
-    const status = JSON.parse(evt.data);
-    if (status.winner != null) {
-      drawWinner(status.winner, playerId);
-    } else if (status.countdown != null) {
-      const content = `Game starts in ${status.countdown}`;
-      if (!textItem) {
+  if (new URLSearchParams(window.location.search).has('debug')) {
+    openDebugWs();
+  }
+
+  // updateCountdown shows the seconds until the start of the game,
+  // counted from the server start time so all clients start together
+  const updateCountdown = ({ countdown, startAt }) => {
+    let seconds = countdown;
+    if (startAt) {
+      const left = startAt + clockOffset - Date.now();
+      seconds = Math.max(0, Math.ceil(left / 1000));
+      clearTimeout(countdownTimeout);
+      if (seconds > 0) {
+        countdownTimeout = setTimeout(() => {
+          updateCountdown({ countdown: seconds - 1, startAt });
+        }, left - (seconds - 1) * 1000);
+      }
+    }
+    const content = `Game starts in ${seconds}`;
+    if (!textItem) {
+      if (seconds) {
         textItem = createMessage(content);
         messageLayer.addChild(textItem);
-      } else if (status.countdown) {
-        textItem.content = content;
-      } else {
-        textItem.remove();
-        textItem = null;
       }
+    } else if (seconds) {
+      textItem.content = content;
     } else {
-      const playerKeys = Object.keys(status.players);
-      const playerSpan = document.getElementById(`player${playerKeys[0]}`);
-      let playerText = 'Opponent';
-      if (playerId == null) {
-        const myPlayer = Object.values(status.players).find((p) => p.clientId === clientId);
-        if (myPlayer) {
-          playerId = parseInt(playerKeys[0], 10);
-          openCmdWs(playerId);
-          playerText = 'Me';
-          playerSpan.innerHTML = playerText;
+      textItem.remove();
+      textItem = null;
+    }
+  };
+  const updatePlayers = (players) => {
+    Object.entries(players).forEach(([id, player]) => {
+      const playerSpan = getPlayerSpan(id);
+      if (playerSpan.innerHTML === '') {
+        if (parseInt(id, 10) === playerId) {
+          playerSpan.innerHTML = 'Me';
+        } else {
+          playerSpan.innerHTML = player.bot ? `Bot, ${player.personality}` : 'Opponent';
         }
       }
+    });
+    movePlayers(players);
+  };
+  // applySnapshot draws the points the client hasn't seen yet
+  // and acknowledges the snapshot
+  const applySnapshot = ({ tick, players }) => {
+    if (tick <= lastTick) {
+      return;
+    }
+    Object.entries(players).forEach(([id, player]) => {
+      const playerSpan = getPlayerSpan(id);
       if (playerSpan.innerHTML === '') {
-        playerSpan.innerHTML = playerText;
+        if (parseInt(id, 10) === playerId) {
+          playerSpan.innerHTML = 'Me';
+        } else {
+          playerSpan.innerHTML = player.bot ? `Bot, ${player.personality}` : 'Opponent';
+        }
       }
-      movePlayers(status.players);
+      (player.points || []).forEach(([pointTick, x, y, trace]) => {
+        if (pointTick > lastTick) {
+          markFieldAsUsed(id, { x, y, trace: trace === 1 });
+        }
+      });
+      document.getElementById(`ping${id}`).innerHTML = player.ping ? `${player.ping}ms` : '';
+      if (parseInt(id, 10) === playerId) {
+        createOrMoveTriangle(id, { ...player, rotation: predictRotation(player) });
+      } else {
+        createOrMoveTriangle(id, player);
+      }
+    });
+    lastTick = tick;
+    ws.send(JSON.stringify({ type: 'ack', tick }));
+  };
+
+  const onMessage = (evt) => {
+    const status = typeof evt.data === 'string' ? JSON.parse(evt.data) : decodeBinary(evt.data);
+    switch (status.type) {
+      case 'assigned':
+        playerId = status.playerId;
+        // inputs of a previous connection are either processed or lost
+        pendingInputs = [];
+        if (status.token) {
+          seatToken = status.token;
+          sessionStorage.setItem(resumeKey, status.token);
+          sessionStorage.removeItem(seatKey);
+        }
+        break;
+      case 'rematchOffer':
+        rematchOffer = status;
+        break;
+      case 'players':
+        updateProfiles(status.players);
+        break;
+      case 'result':
+        gameOver = true;
+        sessionStorage.removeItem(resumeKey);
+        drawWinner(status.winner, playerId, status.forfeited);
+        break;
+      case 'countdown':
+        updateCountdown(status);
+        break;
+      case 'ping':
+        clockOffset = status.offset;
+        ws.send(JSON.stringify({ type: 'pong', time: status.time, clientTime: Date.now() }));
+        break;
+      case 'player':
+        showPlayerEvent(status);
+        break;
+      case 'state':
+        updatePlayers(status.players);
+        break;
+      case 'snapshot':
+        applySnapshot(status);
+        break;
+      case 'error':
+        gameOver = true;
+        sessionStorage.removeItem(resumeKey);
+        // eslint-disable-next-line no-console
+        console.error(status.error);
+        break;
+      default:
+        // eslint-disable-next-line no-console
+        console.log(status);
     }
   };
-  // eslint-disable-next-line no-console
-  mainWs.onerror = console.error;
+  // connect joins the game, or resumes it with the token
+  // of the previous connection
+  const connect = () => {
+    const socket = new WebSocket(`${WEBSOCKET_BASE_URL}/${gameId}/play`, SUBPROTOCOLS);
+    socket.binaryType = 'arraybuffer';
+    socket.onopen = () => {
+      ws = socket;
+      const resume = sessionStorage.getItem(resumeKey) || undefined;
+      const seat = resume ? undefined : sessionStorage.getItem(seatKey) || undefined;
+      ws.send(JSON.stringify({
+        type: 'join', version: PROTOCOL_VERSION, resume, seat,
+      }));
+    };
+    socket.onclose = () => {
+      ws = null;
+      if (!gameOver) {
+        setTimeout(connect, RECONNECT_DELAY);
+      }
+    };
+    socket.onmessage = onMessage;
+    // eslint-disable-next-line no-console
+    socket.onerror = console.error;
+  };
+  connect();
 
+  // sendInput sends the key press or release and remembers it
+  // for the prediction until the server processed it
+  const sendInput = (dir, key) => {
+    inputSeq += 1;
+    const input = {
+      type: 'input', dir, key, seq: inputSeq, time: Date.now(),
+    };
+    pendingInputs.push(input);
+    ws.send(JSON.stringify(input));
+  };
   document.onkeydown = (event) => {
-    if (cmdWs) {
+    if (ws) {
       if (event.repeat) { return; }
       if (event.key === LEFT_KEY) {
-        cmdWs.send(JSON.stringify({ dir: DOWN, key: LEFT }));
+        sendInput(DOWN, LEFT);
       } else if (event.key === RIGHT_KEY) {
-        cmdWs.send(JSON.stringify({ dir: DOWN, key: RIGHT }));
+        sendInput(DOWN, RIGHT);
       }
     }
   };
   document.onkeyup = (event) => {
-    if (cmdWs) {
+    if (ws) {
       if (event.key === LEFT_KEY) {
-        cmdWs.send(JSON.stringify({ dir: UP, key: LEFT }));
+        sendInput(UP, LEFT);
       } else if (event.key === RIGHT_KEY) {
-        cmdWs.send(JSON.stringify({ dir: UP, key: RIGHT }));
+        sendInput(UP, RIGHT);
       }
     }
   };
@@ -182,8 +565,8 @@ window.addEventListener('load', () => {
     if (event) {
       event.preventDefault();
     }
-    if (cmdWs) {
-      cmdWs.send(JSON.stringify({ dir, key }));
+    if (ws) {
+      sendInput(dir, key);
     }
   };
   document.getElementById(LEFT).addEventListener('mousedown', (e) => {
@@ -210,4 +593,5 @@ window.addEventListener('load', () => {
   document.getElementById(RIGHT).addEventListener('touchend', (e) => {
     sendRotationEvent(e, UP, RIGHT);
   });
+  document.getElementById('rematch').addEventListener('click', acceptRematch);
 });
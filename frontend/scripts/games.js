@@ -0,0 +1,82 @@
+const GAMES_URL = `${window.location.origin}/api/games`;
+const PAGE_SIZE = 10;
+const REFRESH_INTERVAL = 5000;
+let offset = 0;
+let filter = {};
+
+function describe(game) {
+  const bots = game.settings.bots === 1 ? '1 bot' : `${game.settings.bots} bots`;
+  const minutes = Math.floor(game.age / 60);
+  const age = minutes > 0 ? `${minutes} min` : `${game.age} s`;
+  return `${game.players}/${game.settings.capacity} players, ${bots}, `
+    + `${game.map.width}x${game.map.height} map, created ${age} ago`;
+}
+
+function showGames(page) {
+  const message = document.getElementById('games-message');
+  const list = document.getElementById('games');
+  list.innerHTML = '';
+  message.innerHTML = page.total === 0 ? 'There are no open games at the moment.' : '';
+  page.games.forEach((game) => {
+    const item = document.createElement('li');
+    item.className = 'mb-2';
+    const link = document.createElement('a');
+    link.className = 'btn btn-primary btn-sm mr-2';
+    link.setAttribute('href', game.link);
+    link.textContent = 'Join';
+    item.appendChild(link);
+    item.appendChild(document.createTextNode(describe(game)));
+    list.appendChild(item);
+  });
+  document.getElementById('previous').disabled = page.offset === 0;
+  document.getElementById('next').disabled = page.offset + page.games.length >= page.total;
+}
+
+function loadGames() {
+  const params = new URLSearchParams(filter);
+  params.set('offset', offset);
+  params.set('limit', PAGE_SIZE);
+  fetch(`${GAMES_URL}?${params}`)
+    .then((res) => {
+      if (!res.ok) {
+        throw new Error(res.statusText);
+      }
+      return res.json();
+    })
+    .then((page) => {
+      // the games of the last page may have started in the meantime
+      if (page.games.length === 0 && page.offset > 0) {
+        offset = Math.max(0, page.total - PAGE_SIZE);
+        loadGames();
+        return;
+      }
+      showGames(page);
+    })
+    .catch((err) => {
+      document.getElementById('games-message').innerHTML = `Could not load the games, ${err.message}`;
+    });
+}
+
+window.addEventListener('load', () => {
+  document.getElementById('filter').addEventListener('submit', (evt) => {
+    evt.preventDefault();
+    filter = {};
+    new FormData(evt.target).forEach((value, key) => {
+      if (value !== '') {
+        filter[key] = value;
+      }
+    });
+    offset = 0;
+    loadGames();
+  });
+  document.getElementById('previous').addEventListener('click', () => {
+    offset = Math.max(0, offset - PAGE_SIZE);
+    loadGames();
+  });
+  document.getElementById('next').addEventListener('click', () => {
+    offset += PAGE_SIZE;
+    loadGames();
+  });
+  loadGames();
+  setInterval(loadGames, REFRESH_INTERVAL);
+});
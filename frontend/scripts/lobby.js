@@ -1,47 +1,182 @@
 const BASE_URL = window.location.origin;
 const message = 'Waiting for players to join';
 const unsuccessfulMessage = 'No available players at the moment.';
+const cancelledMessage = 'You left the queue.';
+const partyChangedMessage = 'A member left the party.';
+const readyMessage = 'Match found against';
 const WEBSOCKET_PROTOCOL = window.location.hostname === 'localhost' ? 'ws' : 'wss';
 const WEBSOCKET_BASE_URL = `${WEBSOCKET_PROTOCOL}://${window.location.host}/ws`;
 let ws;
 
+function send(msg) {
+  if (ws && ws.readyState === WebSocket.OPEN) {
+    ws.send(JSON.stringify(msg));
+  }
+}
+
+function describeOpponent(opponent) {
+  if (opponent.bot) {
+    return `${opponent.personality || 'standard'} bot`;
+  }
+  const name = `${opponent.nickname || 'player'} rated ${opponent.rating}`;
+  return opponent.party ? `${name} (party)` : name;
+}
+
+function dequeuedMessage(reason) {
+  switch (reason) {
+    case 'cancelled':
+      return cancelledMessage;
+    case 'partyChanged':
+      return partyChangedMessage;
+    default:
+      return unsuccessfulMessage;
+  }
+}
+
+// showParty shows the members of the party, only the leader can queue it
+function showParty(party) {
+  document.getElementById('party-code').innerHTML = party.code;
+  const link = document.getElementById('party-link');
+  link.innerHTML = `${BASE_URL}${party.link}`;
+  link.setAttribute('href', party.link);
+
+  const members = document.getElementById('party-members');
+  members.innerHTML = '';
+  let isLeader = false;
+  party.members.forEach((member) => {
+    const item = document.createElement('li');
+    let name = member.nickname || `Player ${member.id + 1}`;
+    if (member.id === party.you) {
+      name += ' (you)';
+      isLeader = member.leader;
+    }
+    item.style.color = member.color || '';
+    item.textContent = member.leader ? `${name}, leader, rated ${member.rating}` : `${name}, rated ${member.rating}`;
+    members.appendChild(item);
+  });
+
+  document.getElementById('queue').classList.toggle('d-none', !isLeader || party.queued);
+  document.getElementById('cancel').classList.toggle('d-none', !isLeader);
+  document.getElementById('lobby').classList.toggle('d-none', !party.queued);
+  document.getElementById('party').classList.remove('d-none');
+  // reloading the page joins the same party again
+  window.history.replaceState(null, '', party.link);
+}
+
 window.addEventListener('load', () => {
+  const party = new URLSearchParams(window.location.search).get('party');
   const progressContainer = document.getElementById('lobby');
   const progress = document.getElementById('progress-bar');
-  let progressStatus = 0;
-  document.getElementById('lobby-message').innerHTML = message;
+  const lobbyMessage = document.getElementById('lobby-message');
+  const startedAt = Date.now();
+  lobbyMessage.innerHTML = message;
   document.getElementById('unsuccessful-message').innerHTML = unsuccessfulMessage;
   document.getElementById('retry').setAttribute('href', '/join');
+  if (party) {
+    progressContainer.classList.add('d-none');
+  }
+  const partyMessage = document.getElementById('party-message');
+  let joinedParty = false;
+
+  const showUnsuccessful = (text) => {
+    document.getElementById('unsuccessful-message').innerHTML = text;
+    document.getElementById('unsuccessful').classList.remove('d-none');
+    progressContainer.classList.add('d-none');
+  };
 
-  setTimeout(() => {
-    ws = new WebSocket(`${WEBSOCKET_BASE_URL}/lobby`);
-    ws.onopen = () => {};
-    ws.onclose = () => {
-      ws = null;
+  const readyCheck = document.getElementById('ready-check');
+  let readyInterval;
+  const showReadyCheck = (msg) => {
+    let remaining = msg.timeout;
+    const opponents = msg.opponents.map(describeOpponent).join(', ');
+    const update = () => {
+      document.getElementById('ready-message').innerHTML = `${readyMessage} ${opponents}, confirm within ${remaining}s`;
+      remaining = Math.max(remaining - 1, 0);
     };
-    ws.onmessage = (evt) => {
-      if (!evt.data) {
-        return;
-      }
-      if (evt.data === 'ping') {
-        ws.send('success');
-      } else {
-        window.location = `${BASE_URL}/g/${evt.data}`;
+    update();
+    clearInterval(readyInterval);
+    readyInterval = setInterval(update, 1000);
+    document.getElementById('confirm').classList.remove('disabled');
+    readyCheck.classList.remove('d-none');
+    progressContainer.classList.add('d-none');
+  };
+  const hideReadyCheck = () => {
+    clearInterval(readyInterval);
+    readyCheck.classList.add('d-none');
+  };
+
+  document.getElementById('cancel').addEventListener('click', () => {
+    send({ type: 'cancel' });
+  });
+  document.getElementById('queue').addEventListener('click', () => {
+    partyMessage.innerHTML = '';
+    send({ type: 'queue' });
+  });
+  document.getElementById('confirm').addEventListener('click', (evt) => {
+    evt.target.classList.add('disabled');
+    send({ type: 'ready', ready: true });
+  });
+  document.getElementById('decline').addEventListener('click', () => {
+    send({ type: 'ready', ready: false });
+    hideReadyCheck();
+    progressContainer.classList.remove('d-none');
+  });
+
+  const query = party ? `?party=${encodeURIComponent(party)}` : '';
+  ws = new WebSocket(`${WEBSOCKET_BASE_URL}/lobby${query}`);
+  ws.onclose = () => {
+    ws = null;
+  };
+  ws.onmessage = (evt) => {
+    const msg = JSON.parse(evt.data);
+    switch (msg.type) {
+      case 'ping':
+        send({ type: 'pong', time: msg.time, clientTime: Date.now() });
+        break;
+      case 'queued': {
+        hideReadyCheck();
+        progressContainer.classList.remove('d-none');
+        const waited = (Date.now() - startedAt) / 1000;
+        const total = waited + msg.estimatedWait;
+        const percent = total > 0 ? Math.round((100 * waited) / total) : 100;
+        lobbyMessage.innerHTML = `${message} (position ${msg.position}, about ${msg.estimatedWait}s left)`;
+        progress.setAttribute('aria-valuenow', `${percent}`);
+        progress.style.width = `${percent}%`;
+        break;
       }
-    };
-    // eslint-disable-next-line no-console
-    ws.onerror = console.error;
-  }, 1200);
-
-  const intervalId = setInterval(() => {
-    progressStatus += 1;
-    if (progressStatus > 60) {
-      clearInterval(intervalId);
-      document.getElementById('unsuccessful').classList.remove('d-none');
-      progressContainer.classList.add('d-none');
-      ws = null;
+      case 'readyCheck':
+        showReadyCheck(msg);
+        break;
+      case 'match':
+        hideReadyCheck();
+        lobbyMessage.innerHTML = `Playing against ${msg.opponents.map(describeOpponent).join(', ')}`;
+        // the game may be hosted by another server
+        window.location = `${msg.host || BASE_URL}/g/${msg.game}`;
+        break;
+      case 'party':
+        joinedParty = true;
+        showParty(msg);
+        break;
+      case 'dequeued':
+        hideReadyCheck();
+        if (party) {
+          // the party stays together and can be queued again
+          partyMessage.innerHTML = dequeuedMessage(msg.reason);
+          progressContainer.classList.add('d-none');
+        } else {
+          showUnsuccessful(dequeuedMessage(msg.reason));
+        }
+        break;
+      case 'error':
+        if (joinedParty) {
+          partyMessage.innerHTML = msg.error;
+        } else if (party) {
+          showUnsuccessful(msg.error);
+        }
+        break;
+      default:
     }
-    progress.setAttribute('aria-valuenow', `${progressStatus}`);
-    progress.style.width = `${Math.round(progressStatus / 0.6)}%`;
-  }, 1000);
+  };
+  // eslint-disable-next-line no-console
+  ws.onerror = console.error;
 });
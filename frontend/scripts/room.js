@@ -0,0 +1,86 @@
+const BASE_URL = window.location.origin;
+const WEBSOCKET_PROTOCOL = window.location.hostname === 'localhost' ? 'ws' : 'wss';
+const WEBSOCKET_BASE_URL = `${WEBSOCKET_PROTOCOL}://${window.location.host}/ws`;
+let ws;
+let ready = false;
+
+function send(message) {
+  if (ws && ws.readyState === WebSocket.OPEN) {
+    ws.send(JSON.stringify(message));
+  }
+}
+
+function showRoom(room) {
+  document.getElementById('room-code').innerHTML = room.code;
+  const link = document.getElementById('room-link');
+  link.innerHTML = `${BASE_URL}${room.link}`;
+  link.setAttribute('href', room.link);
+
+  const members = document.getElementById('members');
+  members.innerHTML = '';
+  let isHost = false;
+  room.members.forEach((member) => {
+    const item = document.createElement('li');
+    let name = member.nickname || `Player ${member.id + 1}`;
+    if (member.id === room.you) {
+      name += ' (you)';
+    }
+    let status = member.ready ? 'ready' : 'not ready';
+    if (member.host) {
+      status = 'host';
+    }
+    item.style.color = member.color || '';
+    item.textContent = `${name}, ${status}`;
+    members.appendChild(item);
+    if (member.id === room.you) {
+      isHost = member.host;
+      ({ ready } = member);
+    }
+  });
+
+  document.getElementById('bots').value = `${room.settings.bots}`;
+  document.getElementById('settings').classList.toggle('d-none', !isHost);
+  const readyButton = document.getElementById('ready');
+  readyButton.classList.toggle('d-none', isHost);
+  readyButton.innerHTML = ready ? 'Not ready' : 'Ready';
+}
+
+window.addEventListener('load', () => {
+  const code = window.location.pathname.split('/').pop();
+  const roomMessage = document.getElementById('room-message');
+
+  ws = new WebSocket(`${WEBSOCKET_BASE_URL}/room/${code}`);
+  ws.onclose = () => {
+    ws = null;
+  };
+  ws.onmessage = (evt) => {
+    const msg = JSON.parse(evt.data);
+    switch (msg.type) {
+      case 'room':
+        roomMessage.innerHTML = '';
+        showRoom(msg);
+        break;
+      case 'start':
+        // the game page takes the seat reserved for this member
+        sessionStorage.setItem(`seat-${msg.game}`, msg.seat);
+        window.location = `${BASE_URL}/g/${msg.game}`;
+        break;
+      case 'error':
+        roomMessage.innerHTML = msg.error;
+        break;
+      default:
+    }
+  };
+  // eslint-disable-next-line no-console
+  ws.onerror = console.error;
+
+  document.getElementById('ready').addEventListener('click', () => {
+    send({ type: 'ready', ready: !ready });
+  });
+  document.getElementById('bots').addEventListener('change', (evt) => {
+    send({ type: 'settings', bots: parseInt(evt.target.value, 10) });
+  });
+  document.getElementById('start').addEventListener('click', () => {
+    send({ type: 'start' });
+  });
+});
@@ -1,14 +1,14 @@
 package main
 
 import (
-	"encoding/json"
-	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/lazareviczoran/blaster-twister/protocol"
 )
 
 const width int = 500
@@ -16,148 +16,246 @@ const height int = 600
 
 // Game holds the connections to the players
 type Game struct {
-	id        string
-	players   map[int]Player
-	lobby     chan int
-	register  chan Player
-	endGame   chan Player
-	broadcast chan []byte
+	id      string
+	players map[int]Player
+	// players of the started game, which never changes afterwards,
+	// so the bots can read it while the game removes the players
+	lineup   []Player
+	register chan Player
+	endGame  chan Player
+	// players which didn't reconnect within the grace period
+	forfeit   chan Player
+	forfeited []int
+	// connection changes of the human players
+	presence chan protocol.PlayerEvent
+	// closed when the game is over
+	done      chan struct{}
+	broadcast chan protocol.Message
 	board     *Board
+	history   *snapshotHistory
 	winner    Player
 	createdAt time.Time
-	available bool
-	started   bool
+	capacity  int
+	// number of reserved seats, used as the id of the next player
+	seats int
+	// ids of the seats given up before the start, which are taken first
+	vacated []int
+	// whether all seats are reserved for invited players, like in private
+	// rooms and rematches
+	reserved bool
+	seatsMu  sync.Mutex
+	// human players by their seat tokens
+	seated map[string]*Human
+	// ids of the seats reserved for players by the tokens they join with
+	invited map[string]int
+	// wins of the players in this game and the games it is a rematch of
+	series *series
+	// difficulty of the bots which join the game
+	botDifficulty Difficulty
+	// whether the results adjust the difficulty for the players session
+	adaptive bool
+	// whether the result updates the ratings of the players sessions
+	rated bool
+	// games of private rooms are never listed in the game browser
+	private bool
+	// number of bots the game was created with
+	bots int
+	// stream of the bot decisions for debugging
+	debug   *debugHub
+	started bool
 }
 
 func (g *Game) run() {
 	timeoutTicker := time.NewTicker(3 * time.Minute)
 	defer timeoutTicker.Stop()
-	joinedPlayers := 0
+	snapshotTicker := time.NewTicker(1000 / fps * time.Millisecond)
+	defer snapshotTicker.Stop()
 	for {
 		select {
-		case <-g.lobby:
-			joinedPlayers++
-			if joinedPlayers == 2 {
-				g.available = false
-			}
 		case player := <-g.register:
 			g.players[player.ID()] = player
-			if len(g.players) == 2 {
+			g.sendToAll(g.playerInfo())
+			if len(g.players) == g.capacity {
 				g.startGame()
 			}
 		case player := <-g.endGame:
-			player.SetAlive(false)
-			var winner Player
-			alivePlayers := 0
-			for _, p := range g.players {
-				if p.IsAlive() {
-					alivePlayers++
-					winner = p
-				}
+			if g.eliminate(player, protocol.CauseCrashed) {
+				return
 			}
-			if alivePlayers == 1 {
-				g.winner = winner
-				temp := make(map[string]interface{})
-				temp["winner"] = winner.ID()
-				res, err := json.Marshal(&temp)
-				if err != nil {
-					log.Printf("Could not convert to JSON, %v", err)
+		case player := <-g.forfeit:
+			log.Printf("Player %d forfeited game %s", player.ID(), g.id)
+			if g.lineup == nil {
+				// nobody has played yet, so nobody wins
+				if g.vacate(player) {
+					return
 				}
-				g.sendToAll(res)
-				g.destroyPlayers()
-				g.stop()
-				delete(activeGames, g.id)
+				continue
+			}
+			g.forfeited = append(g.forfeited, player.ID())
+			if g.eliminate(player, protocol.CauseDisconnected) {
 				return
 			}
+		case event := <-g.presence:
+			log.Printf("Player %d of game %s %s", event.PlayerID, g.id, event.Event)
+			g.sendToAll(event)
+			if p, ok := g.players[event.PlayerID]; ok && event.Event == protocol.EventReconnected {
+				p.Broadcast(g.playerInfo())
+			}
 		case message := <-g.broadcast:
 			g.sendToAll(message)
+		case <-snapshotTicker.C:
+			if g.started {
+				g.sendSnapshots()
+			}
 		case <-timeoutTicker.C:
 			log.Printf("There are no active players to join, closing game %s", g.id)
 			g.stop()
-			delete(activeGames, g.id)
+			activeGames.Remove(g.id)
+			return
 		}
 	}
 }
 
-func newGame(id string, height, width int) *Game {
-	return &Game{
-		id:        id,
-		broadcast: make(chan []byte),
-		lobby:     make(chan int),
-		register:  make(chan Player),
-		endGame:   make(chan Player),
-		players:   make(map[int]Player),
-		board:     initBoard(height, width),
-		winner:    nil,
-		createdAt: time.Now(),
-		available: true,
-		started:   false,
-	}
-}
-
-func createGame() (string, error) {
-	gameID := randToken()
-	game := newGame(gameID, height, width)
-	activeGames[gameID] = game
-	go game.run()
-
-	return gameID, nil
+// eliminate removes the player from the game and ends the game when
+// a single player is left, it returns whether the game is over
+func (g *Game) eliminate(player Player, cause string) bool {
+	if !player.IsAlive() {
+		return false
+	}
+	player.SetAlive(false)
+	g.sendToAll(protocol.PlayerEvent{PlayerID: player.ID(), Event: protocol.EventEliminated, Cause: cause})
+	var winner Player
+	alivePlayers := 0
+	for _, p := range g.players {
+		if p.IsAlive() {
+			alivePlayers++
+			winner = p
+		}
+	}
+	if alivePlayers != 1 {
+		return false
+	}
+	g.winner = winner
+	g.series.record(winner.ID())
+	g.recordResults()
+	g.recordRatings()
+	g.offerRematch()
+	g.sendToAll(protocol.Result{Winner: winner.ID(), Forfeited: g.forfeited})
+	g.destroyPlayers()
+	g.stop()
+	activeGames.Remove(g.id)
+	return true
 }
 
-func findAvailableGameAndJoin() (string, error) {
-	timeoutTicker := time.NewTicker(time.Minute)
-	countdownTicker := time.NewTicker(time.Second)
-	defer func() {
-		countdownTicker.Stop()
-		timeoutTicker.Stop()
-	}()
-	var oldestGame *Game
+// vacate gives up the seat of the player which forfeited before the start,
+// so another player can take it. The game is closed when no human player
+// is left to wait for the others, or when nobody else may take the seat,
+// it returns whether it was closed.
+func (g *Game) vacate(player Player) bool {
+	player.SetAlive(false)
+	player.Destroy()
+	g.seatsMu.Lock()
+	for token, h := range g.seated {
+		if h == player {
+			delete(g.seated, token)
+		}
+	}
+	if !g.reserved {
+		g.vacated = append(g.vacated, player.ID())
+		g.seats--
+	}
+	g.seatsMu.Unlock()
+	g.sendToAll(protocol.PlayerEvent{PlayerID: player.ID(), Event: protocol.EventEliminated, Cause: protocol.CauseDisconnected})
 
-	for {
-		select {
-		case <-countdownTicker.C:
-			for _, game := range activeGames {
-				if game.available && (oldestGame == nil || oldestGame.createdAt.After(game.createdAt)) {
-					oldestGame = game
-				}
-			}
-			if oldestGame != nil {
-				oldestGame.lobby <- 1
-				return oldestGame.id, nil
-			}
-		case <-timeoutTicker.C:
-			return "", errors.New("There are no active games")
+	waiting := false
+	for _, p := range g.players {
+		if _, ok := p.(*Human); ok {
+			waiting = true
 		}
 	}
+	if waiting && !g.reserved {
+		g.sendToAll(g.playerInfo())
+		return false
+	}
+	if waiting {
+		g.sendToAll(protocol.Error{Error: "A player left before the start"})
+	}
+	log.Printf("Game %s can't start anymore, closing it", g.id)
+	g.destroyPlayers()
+	g.stop()
+	activeGames.Remove(g.id)
+	return true
+}
+
+func newGame(id string, capacity, height, width int) *Game {
+	return &Game{
+		id:            id,
+		broadcast:     make(chan protocol.Message),
+		register:      make(chan Player),
+		endGame:       make(chan Player),
+		forfeit:       make(chan Player),
+		presence:      make(chan protocol.PlayerEvent),
+		done:          make(chan struct{}),
+		seated:        make(map[string]*Human),
+		invited:       make(map[string]int),
+		series:        newSeries(capacity),
+		players:       make(map[int]Player),
+		board:         initBoard(height, width),
+		history:       &snapshotHistory{},
+		winner:        nil,
+		createdAt:     time.Now(),
+		capacity:      capacity,
+		botDifficulty: maxDifficulty,
+		debug:         newDebugHub(),
+		started:       false,
+	}
+}
+
+func createGame(capacity int) (*Game, error) {
+	return createGameWithID(randToken(), capacity)
+}
+
+// createGameWithID creates a game with an id chosen in advance,
+// e.g. by the lobby of another server
+func createGameWithID(gameID string, capacity int) (*Game, error) {
+	if capacity < 1 || capacity > maxPlayers {
+		return nil, fmt.Errorf("Invalid number of players %d", capacity)
+	}
+	game := newGame(gameID, capacity, height, width)
+	activeGames.Add(game)
+	go game.run()
+
+	return game, nil
 }
 
 func (g *Game) startGame() {
 	startTime := time.Now()
 	log.Printf("game started at %v", startTime)
 
+	g.lineup = make([]Player, 0, len(g.players))
 	for _, p := range g.players {
-		p.BroadcastCurrentPosition()
+		g.lineup = append(g.lineup, p)
+	}
+	for _, p := range g.lineup {
 		go p.Move()
 	}
 
+	g.sendSnapshots()
 	g.startCountdown()
 }
 
+// startCountdown counts down the seconds until the start. Every message
+// contains the start time, so the clients can start at the same instant.
 func (g *Game) startCountdown() {
 	countdownTicker := time.NewTicker(1000 * time.Millisecond)
 	defer countdownTicker.Stop()
 
 	counter := 3
+	startAt := unixMillis(time.Now().Add(time.Duration(counter+1) * time.Second))
 	for {
 		select {
 		case <-countdownTicker.C:
-			temp := make(map[string]interface{})
-			temp["countdown"] = counter
-			res, err := json.Marshal(&temp)
-			if err != nil {
-				log.Printf("Could not convert to JSON, %v", err)
-			}
-			g.sendToAll(res)
+			g.sendToAll(protocol.Countdown{Countdown: counter, StartAt: startAt})
 			counter--
 			if counter < 0 {
 				g.started = true
@@ -167,45 +265,241 @@ func (g *Game) startCountdown() {
 	}
 }
 
-func (g *Game) sendToAll(message []byte) {
+// sendToAll sends the message to all players, each of them
+// encodes it for its own connection
+func (g *Game) sendToAll(msg protocol.Message) {
 	for _, p := range g.players {
-		p.Broadcast(message)
+		p.Broadcast(msg)
 	}
 }
 
+// notify passes the event of the player to the game loop,
+// events after the end of the game are dropped
+func (g *Game) notify(playerID int, event string) {
+	select {
+	case g.presence <- protocol.PlayerEvent{PlayerID: playerID, Event: event}:
+	case <-g.done:
+	}
+}
+
+// reserveSeat returns the id for a new player, players which
+// connect at the same time always get different ids
+func (g *Game) reserveSeat() (int, bool) {
+	g.seatsMu.Lock()
+	defer g.seatsMu.Unlock()
+	if g.seats >= g.capacity {
+		return 0, false
+	}
+	if n := len(g.vacated); n > 0 {
+		id := g.vacated[n-1]
+		g.vacated = g.vacated[:n-1]
+		g.seats++
+		return id, true
+	}
+	g.seats++
+	return g.seats - 1, true
+}
+
 func connectPlayer(game *Game, w http.ResponseWriter, r *http.Request) {
-	id := len(game.players)
-	if id < 2 {
-		conn, err := upgrader.Upgrade(w, r, nil)
+	// the seat is reserved once the connection is established, requests
+	// which fail to upgrade must not take the seats of the game
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Print("upgrade:", err)
+		return
+	}
+	// clients without a join message speak the legacy version
+	version, err := protocol.Negotiate(legacyVersion)
+	if err != nil {
+		rejectConnection(conn, err.Error())
+		return
+	}
+	id, ok := game.reserveSeat()
+	if !ok {
+		rejectConnection(conn, "Game is full")
+		return
+	}
+
+	session := requestSession(r)
+	createPlayer(game, id, conn, session, profiles.get(session), version)
+}
+
+// connectMultiplexedPlayer connects a player which uses a single websocket
+// for both the game state and the inputs. The client has to send a join
+// message first, which is answered with the assigned player id.
+//
+// A join with the token of a disconnected player resumes its seat instead.
+func connectMultiplexedPlayer(game *Game, w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Print("upgrade:", err)
+		return
+	}
+
+	conn.SetReadDeadline(time.Now().Add(writeWait))
+	_, message, err := conn.ReadMessage()
+	if err != nil {
+		log.Printf("Could not read join message, %v", err)
+		conn.Close()
+		return
+	}
+	msg, err := protocol.Decode(message)
+	join, ok := msg.(*protocol.Join)
+	if !ok {
+		rejectConnection(conn, fmt.Sprintf("Expected join message, got %s (%v)", message, err))
+		return
+	}
+	version, err := protocol.Negotiate(join.Version)
+	if err != nil {
+		rejectConnection(conn, err.Error())
+		return
+	}
+	session := requestSession(r)
+	profile := profiles.get(session)
+	if join.Profile != nil {
+		if profile, err = profiles.set(session, *join.Profile); err != nil {
+			rejectConnection(conn, err.Error())
+			return
+		}
+	}
+	if join.Seat != "" {
+		id, err := game.claimSeat(join.Seat)
+		if err != nil {
+			rejectConnection(conn, err.Error())
+			return
+		}
+		createPlayer(game, id, conn, session, profile, version)
+		return
+	}
+	if join.Resume != "" {
+		player, err := game.seatedPlayer(join.Resume)
 		if err != nil {
-			log.Print("upgrade:", err)
+			rejectConnection(conn, err.Error())
 			return
 		}
+		if err := player.resume(conn, version); err != nil {
+			rejectConnection(conn, err.Error())
+		}
+		return
+	}
+	id, ok := game.reserveSeat()
+	if !ok {
+		rejectConnection(conn, "Game is full")
+		return
+	}
+	createPlayer(game, id, conn, session, profile, version)
+}
 
-		createPlayer(game, id, conn)
+// rejectConnection sends the error to the client and closes the connection
+func rejectConnection(conn *websocket.Conn, reason string) {
+	log.Printf("Rejecting connection: %s", reason)
+	if res, err := protocol.Encode(protocol.Error{Error: reason}); err == nil {
+		conn.SetWriteDeadline(time.Now().Add(writeWait))
+		conn.WriteMessage(websocket.TextMessage, res)
 	}
+	conn.Close()
 }
 
-func connectBot(game *Game) {
-	id := len(game.players)
-	if id < 2 {
-		createPlayer(game, id, nil)
+func connectBot(game *Game, personality Personality) {
+	id, ok := game.reserveSeat()
+	if ok {
+		addBot(game, id, personality)
 	}
 }
 
-func createPlayer(game *Game, id int, conn *websocket.Conn) {
+// addBot adds a bot on the already reserved seat
+func addBot(game *Game, id int, personality Personality) {
+	player := &Bot{newPlayerData(game, id), personality, game.botDifficulty, nil}
+	player.InitPlayer()
+	game.register <- player
+}
+
+// connectBots adds a bot with each of the given personalities to the game
+func connectBots(game *Game, personalities []Personality) {
+	for _, personality := range personalities {
+		connectBot(game, personality)
+	}
+}
+
+func createPlayer(game *Game, id int, conn *websocket.Conn, session string, profile protocol.PlayerProfile, version int) {
+	player := &Human{
+		PlayerData:    newPlayerData(game, id),
+		mainConn:      conn,
+		attach:        make(chan *websocket.Conn, 1),
+		snapshotReady: make(chan struct{}, 1),
+		session:       session,
+		profile:       profile,
+		version:       version,
+		seatToken:     newSeatToken(game.id, id),
+	}
+	game.seatsMu.Lock()
+	game.seated[player.seatToken] = player
+	game.seatsMu.Unlock()
+	player.InitPlayer()
+	game.register <- player
+}
+
+func newPlayerData(game *Game, id int) PlayerData {
 	currentPosition := sync.Map{}
-	send := make(chan []byte, 256)
+	send := make(chan protocol.Message, 256)
 	rotationChannel := make(chan RotationData)
 	stopRotation := make(chan bool)
-	var player Player
-	if conn != nil {
-		player = &Human{PlayerData{id, -1, game, send, &currentPosition, rotationChannel, stopRotation, nil, true}, conn, nil}
-	} else {
-		player = &Bot{PlayerData{id, -1, game, send, &currentPosition, rotationChannel, stopRotation, nil, true}}
+	return PlayerData{id, game, send, &currentPosition, rotationChannel, stopRotation, nil, true}
+}
+
+// playerInfo returns the profiles of the players
+func (g *Game) playerInfo() protocol.Players {
+	info := protocol.Players{Players: make([]protocol.PlayerInfo, 0, len(g.players))}
+	for id := 0; id < g.capacity; id++ {
+		switch p := g.players[id].(type) {
+		case *Human:
+			info.Players = append(info.Players, protocol.PlayerInfo{ID: id, PlayerProfile: p.profile})
+		case *Bot:
+			info.Players = append(info.Players, protocol.PlayerInfo{ID: id, Bot: true, Personality: string(p.personality)})
+		}
+	}
+	return info
+}
+
+// recordResults updates the bot difficulty for the sessions of
+// the human players in adaptive games
+func (g *Game) recordResults() {
+	if !g.adaptive {
+		return
+	}
+	for _, p := range g.players {
+		if h, ok := p.(*Human); ok && h.session != "" {
+			skills.record(h.session, p == g.winner)
+		}
+	}
+}
+
+// recordRatings updates the ratings of the human players in rated games,
+// games in which a session played against itself are not rated
+func (g *Game) recordRatings() {
+	if !g.rated {
+		return
+	}
+	winner, ok := g.winner.(*Human)
+	if !ok || winner.session == "" {
+		return
+	}
+	sessions := map[string]bool{winner.session: true}
+	var losers []string
+	for _, p := range g.players {
+		h, ok := p.(*Human)
+		if !ok || p == g.winner {
+			continue
+		}
+		if h.session == "" || sessions[h.session] {
+			return
+		}
+		sessions[h.session] = true
+		losers = append(losers, h.session)
+	}
+	if len(losers) > 0 {
+		ratings.record(winner.session, losers)
 	}
-	player.InitPlayer()
-	game.register <- player
 }
 
 func (g *Game) destroyPlayers() {
@@ -215,7 +509,8 @@ func (g *Game) destroyPlayers() {
 }
 
 func (g *Game) stop() {
-	close(g.lobby)
+	g.debug.close()
+	close(g.done)
 	close(g.register)
 	close(g.endGame)
 	close(g.broadcast)
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lazareviczoran/blaster-twister/protocol"
+)
+
+// seatTestHuman seats a human player without a connection in the game
+func seatTestHuman(g *Game) *Human {
+	id, ok := g.reserveSeat()
+	if !ok {
+		panic("The game is full")
+	}
+	h := &Human{PlayerData: newPlayerData(g, id), seatToken: newSeatToken(g.id, id)}
+	g.seated[h.seatToken] = h
+	g.players[id] = h
+	return h
+}
+
+// awaitEvent waits for the event of the player among the messages of the human
+func awaitEvent(t *testing.T, h *Human, playerID int, event string) {
+	t.Helper()
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case msg := <-h.send:
+			if e, ok := msg.(protocol.PlayerEvent); ok && e.PlayerID == playerID && e.Event == event {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("Player %d wasn't %s", playerID, event)
+		}
+	}
+}
+
+func TestForfeitBeforeStart(t *testing.T) {
+	g := newGame(randToken(), 3, height, width)
+	activeGames.Add(g)
+	first, second := seatTestHuman(g), seatTestHuman(g)
+	go g.run()
+
+	// the seat of a player leaving before the start is taken by the next one
+	second.forfeit()
+	awaitEvent(t, first, second.id, protocol.EventEliminated)
+	if g.winner != nil {
+		t.Fatalf("Player %d won a game which didn't start", g.winner.ID())
+	}
+	if id, ok := g.reserveSeat(); !ok || id != second.id {
+		t.Errorf("Reserved seat %d, want the vacated seat %d", id, second.id)
+	}
+	if _, err := g.seatedPlayer(second.seatToken); err == nil {
+		t.Error("The seat token of the player which left is still valid")
+	}
+
+	// the game is closed once nobody is waiting for it
+	first.forfeit()
+	select {
+	case <-g.done:
+	case <-time.After(time.Second):
+		t.Fatal("The game wasn't closed without players")
+	}
+	if g.winner != nil {
+		t.Errorf("Player %d won a game which didn't start", g.winner.ID())
+	}
+	if activeGames.Get(g.id) != nil {
+		t.Error("The closed game is still registered")
+	}
+}
+
+func TestForfeitBeforeRematchStart(t *testing.T) {
+	g := newGame(randToken(), 3, height, width)
+	activeGames.Add(g)
+	first, second := seatTestHuman(g), seatTestHuman(g)
+	g.reserved = true
+	go g.run()
+
+	// nobody else may take the seat, so the others can't play
+	second.forfeit()
+	select {
+	case <-g.done:
+	case <-time.After(time.Second):
+		t.Fatal("The game wasn't closed without the invited player")
+	}
+	var closed bool
+	for msg := range first.send {
+		if _, ok := msg.(protocol.Error); ok {
+			closed = true
+		}
+	}
+	if !closed || g.winner != nil {
+		t.Errorf("Got winner %v, want the game closed with an error", g.winner)
+	}
+}
@@ -1,14 +1,18 @@
 package main
 
 import (
-	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
 	"math"
-	"strconv"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/lazareviczoran/blaster-twister/protocol"
 )
 
 const (
@@ -23,18 +27,82 @@ const (
 
 	// Maximum message size allowed from peer.
 	maxMessageSize = 512
+
+	// Send latency pings to the peer with this period.
+	latencyPeriod = 2 * time.Second
+
+	// Clients which are more ticks behind get disconnected.
+	maxSnapshotLag = 5 * fps
+
+	// Key presses arriving later than this compared to the fastest
+	// input of the player are dropped.
+	maxInputLag = 500 * time.Millisecond
 )
 
+// Version of the clients which connect with a separate command connection
+const legacyVersion = 0
+
+// First protocol version which receives snapshots instead of states
+const snapshotVersion = 2
+
+var reconnectGrace = flag.Duration("reconnect-grace", 15*time.Second, "time a disconnected player has to resume the game before forfeiting")
+
 var upgrader = websocket.Upgrader{
 	ReadBufferSize:  1024,
 	WriteBufferSize: 1024,
+	Subprotocols:    protocol.Subprotocols,
 }
 
 // Human represents the websocket player
 type Human struct {
 	PlayerData
+	// current game connection, nil while the player is disconnected
 	mainConn *websocket.Conn
-	cmdConn  *websocket.Conn
+	connMu   sync.Mutex
+	// passes new game connections to MainWritePump
+	attach  chan *websocket.Conn
+	cmdConn *websocket.Conn
+	session string
+	// how the player is shown to the others
+	profile protocol.PlayerProfile
+	// signed token of the players seat, required to resume the game
+	// or to attach the command connection
+	seatToken string
+	// forfeits the game when the player doesn't resume it in time
+	graceTimer *time.Timer
+	// set when the player forfeited or the game is over
+	finished bool
+	// set until a new connection got a snapshot of the whole game
+	resync int32
+	// protocol version negotiated with the client, with the legacy version
+	// the inputs arrive through cmdConn instead of mainConn
+	version int
+	// last snapshot tick written to and acknowledged by the client
+	sentTick  int64
+	ackedTick int64
+	// newest snapshot which wasn't written yet, MainWritePump
+	// is woken up through snapshotReady
+	snapshotMu      sync.Mutex
+	pendingSnapshot *protocol.Snapshot
+	snapshotReady   chan struct{}
+	// tick at which the current connection was attached
+	attachedTick int64
+	// messages dropped on the current connection
+	dropped int64
+	// round trip time and clock offset of the current connection
+	// in milliseconds, guarded by latencyMu
+	latencyMu   sync.Mutex
+	rtt         int64
+	clockOffset int64
+	hasLatency  bool
+	// sequence number of the last input applied by Move
+	lastInput int64
+	// newest input received and the smallest difference between the
+	// server and the client time seen so far, guarded by inputMu
+	inputMu       sync.Mutex
+	receivedInput int
+	minInputLag   int64
+	hasInputLag   bool
 }
 
 // ID returns the players Id
@@ -42,15 +110,6 @@ func (h *Human) ID() int {
 	return h.id
 }
 
-// ClientID returns the id obtained from the WS client
-func (h *Human) ClientID() int {
-	return h.clientID
-}
-
-func (h *Human) setClientID(id int) {
-	h.clientID = id
-}
-
 // Game returns the pointer to Game
 func (h *Human) Game() *Game {
 	return h.game
@@ -77,28 +136,33 @@ func (h *Human) SetAlive(alive bool) {
 // The application runs readPump in a per-connection goroutine. The application
 // ensures that there is at most one reader on a connection by executing all
 // reads from this goroutine.
-func (h *Human) MainReadPump() {
-	h.mainConn.SetReadLimit(maxMessageSize)
-	h.mainConn.SetReadDeadline(time.Now().Add(pongWait))
-	h.mainConn.SetPongHandler(func(string) error { h.mainConn.SetReadDeadline(time.Now().Add(pongWait)); return nil })
+func (h *Human) MainReadPump(conn *websocket.Conn) {
+	defer h.connectionLost(conn)
+	conn.SetReadLimit(maxMessageSize)
+	conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error { conn.SetReadDeadline(time.Now().Add(pongWait)); return nil })
 	for {
-		_, message, err := h.mainConn.ReadMessage()
+		_, message, err := conn.ReadMessage()
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				log.Printf("error: %v", err)
 			}
 			break
 		}
-		var event map[string]string
-		if err := json.Unmarshal(message, &event); err != nil {
-			log.Printf("unmarshal error: %v", err)
+		msg, err := protocol.Decode(message)
+		if err != nil {
+			log.Printf("decode error: %v", err)
+			continue
 		}
-		if event["clientId"] != "" {
-			clientID, err := strconv.Atoi(event["clientId"])
-			if err != nil {
-				log.Printf("Cannot convert %s to int", event["clientId"])
+		switch msg := msg.(type) {
+		case *protocol.Input:
+			if h.version != legacyVersion {
+				h.handleInput(msg)
 			}
-			h.setClientID(clientID)
+		case *protocol.Ack:
+			h.acknowledge(msg.Tick)
+		case *protocol.Pong:
+			h.recordPong(msg)
 		}
 	}
 }
@@ -120,14 +184,185 @@ func (h *Human) CmdReadPump() {
 			}
 			break
 		}
-		var event map[string]string
-		if err := json.Unmarshal(message, &event); err != nil {
-			log.Printf("unmarshal error: %v", err)
+		msg, err := protocol.Decode(message)
+		if err != nil {
+			log.Printf("decode error: %v", err)
+			continue
+		}
+		if input, ok := msg.(*protocol.Input); ok {
+			h.handleInput(input)
+		}
+	}
+	// the player can't steer without the command connection
+	h.connMu.Lock()
+	conn := h.mainConn
+	h.connMu.Unlock()
+	if conn != nil {
+		h.connectionLost(conn)
+	}
+}
+
+// connectionLost gives the player some time to resume the game on a new
+// connection, legacy clients can't resume and forfeit right away
+func (h *Human) connectionLost(conn *websocket.Conn) {
+	h.connMu.Lock()
+	if h.mainConn != conn || h.finished {
+		h.connMu.Unlock()
+		return
+	}
+	h.mainConn = nil
+	h.connMu.Unlock()
+	h.game.notify(h.id, protocol.EventDisconnected)
+
+	// the grace period starts once the other players know about it
+	grace := *reconnectGrace
+	if h.version == legacyVersion {
+		grace = 0
+	}
+	h.connMu.Lock()
+	defer h.connMu.Unlock()
+	if h.mainConn == nil && !h.finished && h.graceTimer == nil {
+		h.graceTimer = time.AfterFunc(grace, h.forfeit)
+	}
+}
+
+// forfeit ends the game for the player if it is still disconnected
+func (h *Human) forfeit() {
+	h.connMu.Lock()
+	if h.mainConn != nil || h.finished || !h.IsAlive() {
+		h.connMu.Unlock()
+		return
+	}
+	h.finished = true
+	h.connMu.Unlock()
+	select {
+	case h.game.forfeit <- h:
+	case <-h.game.done:
+	}
+}
+
+// resume continues the game on the new connection, the client
+// gets a snapshot of the whole game first
+func (h *Human) resume(conn *websocket.Conn, version int) error {
+	if version != h.version {
+		return fmt.Errorf("Cannot resume a game of version %d with version %d", h.version, version)
+	}
+	h.connMu.Lock()
+	if h.finished {
+		h.connMu.Unlock()
+		return errors.New("The game is over")
+	}
+	if h.graceTimer != nil {
+		h.graceTimer.Stop()
+		h.graceTimer = nil
+	}
+	oldConn := h.mainConn
+	h.mainConn = conn
+	h.connMu.Unlock()
+
+	if oldConn != nil {
+		oldConn.Close()
+	}
+	h.resetInputs()
+	select {
+	case h.attach <- conn:
+		go h.MainReadPump(conn)
+		h.game.notify(h.id, protocol.EventReconnected)
+	case <-h.game.done:
+		conn.Close()
+	}
+	return nil
+}
+
+// recordPong updates the round trip time and the clock offset. The round
+// trip time is smoothed, the offset is only taken from the faster round
+// trips, which tell the client time most accurately.
+func (h *Human) recordPong(pong *protocol.Pong) {
+	rtt := unixMillis(time.Now()) - pong.Time
+	if pong.Time == 0 || rtt < 0 || rtt > int64(pongWait/time.Millisecond) {
+		return
+	}
+	offset := pong.ClientTime - (pong.Time + rtt/2)
+	h.latencyMu.Lock()
+	defer h.latencyMu.Unlock()
+	if !h.hasLatency {
+		h.rtt, h.clockOffset, h.hasLatency = rtt, offset, true
+		return
+	}
+	if rtt <= h.rtt {
+		h.clockOffset = offset
+	}
+	h.rtt += (rtt - h.rtt) / 4
+}
+
+// latency returns the round trip time and the clock offset in milliseconds
+func (h *Human) latency() (rtt, offset int64) {
+	h.latencyMu.Lock()
+	defer h.latencyMu.Unlock()
+	return h.rtt, h.clockOffset
+}
+
+// resetLatency forgets the measurements of the previous connection
+func (h *Human) resetLatency() {
+	h.latencyMu.Lock()
+	defer h.latencyMu.Unlock()
+	h.rtt, h.clockOffset, h.hasLatency = 0, 0, false
+}
+
+// handleInput passes the rotation event to the players Move loop.
+//
+// Inputs with a sequence number are applied in order, repeated and out of
+// order inputs are ignored. Key presses which arrive too late are dropped,
+// but still acknowledged so the client stops predicting them. Releasing
+// a key is always applied.
+func (h *Human) handleInput(input *protocol.Input) {
+	if input.Dir != directionDown && input.Dir != directionUp {
+		return
+	}
+	rotationData := RotationData{dir: input.Dir, key: input.Key, seq: input.Seq}
+	if input.Seq > 0 {
+		accepted, inOrder := h.acceptInput(input)
+		if !inOrder {
+			return
 		}
-		if event["dir"] == directionDown || event["dir"] == directionUp {
-			h.rotationChannel <- RotationData{dir: event["dir"], key: event["key"]}
+		if !accepted {
+			log.Printf("Dropping late input %d of player %d", input.Seq, h.id)
+			rotationData = RotationData{seq: input.Seq}
 		}
 	}
+	h.rotationChannel <- rotationData
+}
+
+// resetInputs forgets the inputs of the previous connection,
+// a reloaded client starts counting its inputs again
+func (h *Human) resetInputs() {
+	h.inputMu.Lock()
+	defer h.inputMu.Unlock()
+	h.receivedInput = 0
+	h.hasInputLag = false
+	atomic.StoreInt64(&h.lastInput, 0)
+}
+
+// acceptInput checks whether the input is newer than the previous ones
+// and whether it arrived in time, the lag is measured against the
+// fastest input so far since the client clock isn't synchronized
+func (h *Human) acceptInput(input *protocol.Input) (accepted, inOrder bool) {
+	h.inputMu.Lock()
+	defer h.inputMu.Unlock()
+	if input.Seq <= h.receivedInput {
+		return false, false
+	}
+	h.receivedInput = input.Seq
+	if input.Time == 0 {
+		return true, true
+	}
+	lag := unixMillis(time.Now()) - input.Time
+	if !h.hasInputLag || lag < h.minInputLag {
+		h.minInputLag = lag
+		h.hasInputLag = true
+	}
+	late := lag-h.minInputLag > int64(maxInputLag/time.Millisecond)
+	return !late || input.Dir == directionUp, true
 }
 
 // writePump pumps messages from the hub to the websocket connection.
@@ -135,48 +370,289 @@ func (h *Human) CmdReadPump() {
 // A goroutine running writePump is started for each connection. The
 // application ensures that there is at most one writer to a connection by
 // executing all writes from this goroutine.
+//
+// The pump keeps running while the player is disconnected, the messages
+// are dropped until a new connection is attached.
 func (h *Human) MainWritePump() {
 	ticker := time.NewTicker(pingPeriod)
+	latencyTicker := time.NewTicker(latencyPeriod)
+	var conn *websocket.Conn
 	defer func() {
 		ticker.Stop()
-		h.mainConn.Close()
+		latencyTicker.Stop()
+		if conn != nil {
+			h.detach(conn)
+		}
 	}()
 	for {
+		// a new connection goes first, so that it gets the messages
+		// which were queued after it
+		select {
+		case newConn := <-h.attach:
+			conn = h.attachConn(conn, newConn)
+			continue
+		default:
+		}
 		select {
-		case message, ok := <-h.send:
-			h.mainConn.SetWriteDeadline(time.Now().Add(writeWait))
+		case newConn := <-h.attach:
+			conn = h.attachConn(conn, newConn)
+		case <-h.snapshotReady:
+			if conn == nil {
+				continue
+			}
+			if err := h.writeSnapshot(conn); err != nil {
+				h.detach(conn)
+				conn = nil
+			}
+		case msg, ok := <-h.send:
 			if !ok {
 				// The hub closed the channel.
-				h.mainConn.WriteMessage(websocket.CloseMessage, []byte{})
+				if conn != nil {
+					h.writeSnapshot(conn)
+					conn.SetWriteDeadline(time.Now().Add(writeWait))
+					conn.WriteMessage(websocket.CloseMessage, []byte{})
+				}
 				return
 			}
-
-			w, err := h.mainConn.NextWriter(websocket.TextMessage)
-			if err != nil {
-				return
+			if conn == nil {
+				continue
 			}
-			w.Write(message)
-
-			if err := w.Close(); err != nil {
-				return
+			// the snapshot of the tick before the message goes first
+			err := h.writeSnapshot(conn)
+			if err == nil {
+				conn.SetWriteDeadline(time.Now().Add(writeWait))
+				err = h.writeMessage(conn, msg)
+			}
+			if err != nil {
+				h.detach(conn)
+				conn = nil
 			}
 		case <-ticker.C:
-			h.mainConn.SetWriteDeadline(time.Now().Add(writeWait))
-			if err := h.mainConn.WriteMessage(websocket.PingMessage, nil); err != nil {
-				return
+			if conn == nil {
+				continue
+			}
+			conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				h.detach(conn)
+				conn = nil
+			}
+		case <-latencyTicker.C:
+			if conn == nil {
+				continue
+			}
+			if err := h.writePing(conn); err != nil {
+				h.detach(conn)
+				conn = nil
 			}
 		}
 	}
 }
 
+// attachConn replaces the connection of the player, the new connection
+// starts with the assigned message and a full snapshot. It returns the
+// connection to use, which is nil when writing to it failed.
+func (h *Human) attachConn(conn, newConn *websocket.Conn) *websocket.Conn {
+	if conn != nil {
+		h.detach(conn)
+	}
+	conn = newConn
+	atomic.StoreInt32(&h.resync, 1)
+	atomic.StoreInt64(&h.attachedTick, int64(h.game.history.current()))
+	h.resetLatency()
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	assigned := protocol.Assigned{PlayerID: h.id, Version: h.version, Token: h.seatToken}
+	err := h.writeMessage(conn, assigned)
+	if err == nil {
+		// measure the latency before the countdown
+		err = h.writePing(conn)
+	}
+	if err != nil {
+		h.detach(conn)
+		return nil
+	}
+	return conn
+}
+
+// writePing sends a ping with the latency measured so far,
+// legacy clients don't answer pings
+func (h *Human) writePing(conn *websocket.Conn) error {
+	if h.version == legacyVersion {
+		return nil
+	}
+	rtt, offset := h.latency()
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return h.writeMessage(conn, protocol.Ping{Time: unixMillis(time.Now()), RTT: int(rtt), Offset: offset})
+}
+
+// detach closes the connection and logs how many messages it dropped
+func (h *Human) detach(conn *websocket.Conn) {
+	if dropped := atomic.SwapInt64(&h.dropped, 0); dropped > 0 {
+		log.Printf("Connection of player %d in game %s dropped %d messages", h.id, h.game.id, dropped)
+	}
+	conn.Close()
+}
+
+// writeSnapshot writes the pending snapshot, if there is one
+func (h *Human) writeSnapshot(conn *websocket.Conn) error {
+	h.snapshotMu.Lock()
+	snapshot := h.pendingSnapshot
+	h.pendingSnapshot = nil
+	h.snapshotMu.Unlock()
+	if snapshot == nil {
+		return nil
+	}
+	if atomic.LoadInt32(&h.resync) == 1 {
+		// skip the snapshots based on ticks of the previous connection
+		if snapshot.Base != 0 {
+			return nil
+		}
+		atomic.StoreInt32(&h.resync, 0)
+	}
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	if err := h.writeMessage(conn, *snapshot); err != nil {
+		return err
+	}
+	atomic.StoreInt64(&h.sentTick, int64(snapshot.Tick))
+	return nil
+}
+
+// snapshotBase returns the tick the next snapshot is based on,
+// clients of older versions don't acknowledge the snapshots
+// and new connections need the whole game
+func (h *Human) snapshotBase() int {
+	if atomic.LoadInt32(&h.resync) == 1 {
+		return 0
+	}
+	if h.version < snapshotVersion {
+		return int(atomic.LoadInt64(&h.sentTick))
+	}
+	return int(atomic.LoadInt64(&h.ackedTick))
+}
+
+// sendSnapshot hands the snapshot to MainWritePump. A snapshot which
+// wasn't written yet is replaced, the new one contains its points as well.
+// A client which stays behind for too long is disconnected.
+func (h *Human) sendSnapshot(snapshot protocol.Snapshot) {
+	h.connMu.Lock()
+	conn := h.mainConn
+	h.connMu.Unlock()
+	if conn == nil {
+		return
+	}
+	if behind := snapshot.Tick - h.confirmedTick(); behind > maxSnapshotLag {
+		log.Printf("Disconnecting player %d of game %s, %d ticks behind", h.id, h.game.id, behind)
+		conn.Close()
+		return
+	}
+
+	h.snapshotMu.Lock()
+	if h.pendingSnapshot != nil {
+		atomic.AddInt64(&h.dropped, 1)
+	}
+	h.pendingSnapshot = &snapshot
+	h.snapshotMu.Unlock()
+	select {
+	case h.snapshotReady <- struct{}{}:
+	default:
+	}
+}
+
+// confirmedTick returns the last tick the client is known to have,
+// but not before the tick at which the connection was attached
+func (h *Human) confirmedTick() int {
+	tick := atomic.LoadInt64(&h.ackedTick)
+	if h.version < snapshotVersion {
+		tick = atomic.LoadInt64(&h.sentTick)
+	}
+	if attached := atomic.LoadInt64(&h.attachedTick); attached > tick {
+		tick = attached
+	}
+	return int(tick)
+}
+
+// acknowledge stores the tick of the last snapshot applied by the client,
+// acknowledgements of old or unknown ticks are ignored
+func (h *Human) acknowledge(tick int) {
+	if int64(tick) > atomic.LoadInt64(&h.sentTick) {
+		return
+	}
+	for {
+		acked := atomic.LoadInt64(&h.ackedTick)
+		if int64(tick) <= acked || atomic.CompareAndSwapInt64(&h.ackedTick, acked, int64(tick)) {
+			return
+		}
+	}
+}
+
+// legacyStates converts the snapshot to a state message for each step
+// of each player, as expected by the clients before snapshotVersion
+func legacyStates(snapshot protocol.Snapshot) []protocol.State {
+	ids := make([]int, 0, len(snapshot.Players))
+	for id := range snapshot.Players {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	var states []protocol.State
+	for _, id := range ids {
+		p := snapshot.Players[id]
+		if len(p.Points) == 0 && snapshot.Base == 0 {
+			states = append(states, protocol.State{Players: map[int]protocol.PlayerState{id: p.PlayerState}})
+		}
+		for _, point := range p.Points {
+			state := p.PlayerState
+			state.X, state.Y, state.Trace = point[1], point[2], point[3] == 1
+			states = append(states, protocol.State{Players: map[int]protocol.PlayerState{id: state}})
+		}
+	}
+	return states
+}
+
+// writeMessage encodes the message with the codec of the connection,
+// messages without a binary encoding are sent as JSON, and so are all
+// messages to the clients of versions before the current binary frames
+func (h *Human) writeMessage(conn *websocket.Conn, msg protocol.Message) error {
+	if snapshot, ok := msg.(protocol.Snapshot); ok && h.version < snapshotVersion {
+		for _, state := range legacyStates(snapshot) {
+			if err := h.writeMessage(conn, state); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if conn.Subprotocol() == protocol.BinarySubprotocol && h.version >= protocol.BinaryVersion {
+		if data, ok := protocol.EncodeBinary(msg); ok {
+			return conn.WriteMessage(websocket.BinaryMessage, data)
+		}
+	}
+	data, err := protocol.Encode(msg)
+	if err != nil {
+		log.Printf("Could not encode %s message, %v", msg.MessageType(), err)
+		return nil
+	}
+	return conn.WriteMessage(websocket.TextMessage, data)
+}
+
 // Broadcast sends the message to writePump, which eventually sends it
-// to the websocket client
-func (h *Human) Broadcast(message []byte) {
-	h.send <- message
+// to the websocket client. The message is dropped when the send queue
+// is full, so a stalled client never blocks the game.
+func (h *Human) Broadcast(msg protocol.Message) {
+	select {
+	case h.send <- msg:
+	default:
+		atomic.AddInt64(&h.dropped, 1)
+		log.Printf("Dropping %s message for player %d, the send queue is full", msg.MessageType(), h.id)
+	}
 }
 
 // Destroy closes all channels and removes player from the game
 func (h *Human) Destroy() {
+	h.connMu.Lock()
+	h.finished = true
+	if h.graceTimer != nil {
+		h.graceTimer.Stop()
+	}
+	h.connMu.Unlock()
 	h.StopRotation()
 	close(h.send)
 	delete(h.game.players, h.id)
@@ -185,10 +661,10 @@ func (h *Human) Destroy() {
 // InitPlayer initializes the players position and opens read/write channels
 func (h *Human) InitPlayer() {
 	go h.MainWritePump()
-	go h.MainReadPump()
+	h.attach <- h.mainConn
+	go h.MainReadPump(h.mainConn)
 
-	startX := width / 2
-	startY := height/5 + h.id*3*height/5
+	startX, startY := startPosition(h.id, h.game.capacity)
 	h.currentPosition.Store("x", startX)
 	h.currentPosition.Store("y", startY)
 	h.currentPosition.Store("rotation", getStartRotation())
@@ -196,8 +672,9 @@ func (h *Human) InitPlayer() {
 	h.game.board.fields[startX][startY].setUsed(h)
 }
 
+// AttachWriteConn attaches the command connection of the player
 func (h *Human) AttachWriteConn(conn *websocket.Conn) {
-	log.Printf("Attaching connection to %d", h.ClientID())
+	log.Printf("Attaching command connection to player %d of game %s", h.id, h.game.id)
 	h.cmdConn = conn
 	go h.CmdReadPump()
 }
@@ -206,7 +683,7 @@ func (h *Human) AttachWriteConn(conn *websocket.Conn) {
 // the players rotation angle on each tick
 func (h *Human) StartRotation(direction string) {
 	h.StopRotation()
-	h.rotationTicker = time.NewTicker(30 * time.Millisecond)
+	h.rotationTicker = time.NewTicker(rotationInterval)
 	go func() {
 		for {
 			select {
@@ -214,9 +691,9 @@ func (h *Human) StartRotation(direction string) {
 				currRotation, _ := h.currentPosition.Load("rotation")
 
 				if direction == directionRight {
-					h.currentPosition.Store("rotation", (currRotation.(int)+5)%360)
+					h.currentPosition.Store("rotation", (currRotation.(int)+rotationStep)%360)
 				} else {
-					h.currentPosition.Store("rotation", (currRotation.(int)+355)%360)
+					h.currentPosition.Store("rotation", (currRotation.(int)+360-rotationStep)%360)
 				}
 				h.currentPosition.Store("rotationDir", direction)
 			case <-h.stopRotation:
@@ -250,23 +727,13 @@ func (h *Human) StopRotationWs(direction *string) {
 	}
 }
 
-// BroadcastCurrentPosition sends the players current position
-// to all clients
-func (h *Human) BroadcastCurrentPosition() {
-	temp := make(map[string]interface{})
-	playerPositionMap := syncMapToMap(h.currentPosition)
-	playerPositionMap["clientId"] = h.ClientID()
-	playersStatusMap := make(map[int]interface{})
-	playersStatusMap[h.id] = playerPositionMap
-	temp["players"] = playersStatusMap
-
-	res, err := json.Marshal(&temp)
-	if err != nil {
-		log.Printf("Could not convert to JSON, %v", err)
-		return
-	}
-
-	h.game.sendToAll(res)
+// State returns the players current position
+func (h *Human) State() protocol.PlayerState {
+	state := positionState(h.currentPosition)
+	state.LastInput = int(atomic.LoadInt64(&h.lastInput))
+	rtt, _ := h.latency()
+	state.Ping = int(rtt)
+	return state
 }
 
 // Move sets the new position for the player calculated by
@@ -280,9 +747,7 @@ func (h *Human) Move() {
 	for {
 		select {
 		case <-mainTicker.C:
-			if !h.game.started {
-				h.BroadcastCurrentPosition()
-			} else {
+			if h.game.started {
 				if !h.alive || h.game.winner != nil {
 					visitedTicker.Stop()
 					return
@@ -299,6 +764,9 @@ func (h *Human) Move() {
 			} else if rotationData.dir == directionUp {
 				h.StopRotationWs(&rotationData.key)
 			}
+			if rotationData.seq > 0 {
+				atomic.StoreInt64(&h.lastInput, int64(rotationData.seq))
+			}
 		case <-visitedTicker.C:
 			trace, _ := h.currentPosition.Load("trace")
 			h.currentPosition.Store("trace", !trace.(bool))
@@ -1,69 +1,793 @@
 package main
 
 import (
-	"errors"
+	"flag"
+	"fmt"
 	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/lazareviczoran/blaster-twister/protocol"
+)
+
+// Number of players in games created by the lobby
+const lobbyGamePlayers = 2
+
+const (
+	// Rating difference a candidate accepts as soon as it joins
+	initialMatchRange = 100.0
+	// Growth of the accepted rating difference per second of waiting
+	matchRangeGrowth = 20.0
+	// Largest rating difference, beyond which players wait for the bots
+	maxMatchRange = 800.0
 )
 
-var lobby *Lobby
+// Time a candidate who missed a ready check can't be matched
+const readyPenalty = 30 * time.Second
+
+var botBackfillWait = flag.Duration("bot-backfill", 20*time.Second, "time after which a player waiting in the lobby plays against bots, 0 disables it")
+
+var readyTimeout = flag.Duration("ready-timeout", 10*time.Second, "time matched players in the lobby have to confirm that they are ready")
 
+var queueTimeout = flag.Duration("queue-timeout", 60*time.Second, "time after which a player waiting in the lobby is sent away")
+
+var lobby Matchmaker
+
+// Matchmaker is the lobby the candidates connected to this server wait in.
+// The Lobby keeps the queue in memory, the lobbyRelay shares it with the
+// other servers through the store.
+type Matchmaker interface {
+	// Enqueue adds the candidate alone at the end of the queue
+	Enqueue(c *Candidate)
+	// JoinParty adds the candidate to the party with the code, an empty
+	// code creates a new party led by the candidate
+	JoinParty(c *Candidate, code string)
+	// Queue adds the party led by the candidate at the end of the queue
+	Queue(c *Candidate)
+	// Cancel removes the party of the candidate from the queue, a
+	// candidate which came alone is sent away
+	Cancel(c *Candidate)
+	// Disconnect removes the candidate whose connection is lost,
+	// its party leaves the queue
+	Disconnect(c *Candidate)
+	// Confirm tells the lobby that the candidate is ready for its match
+	Confirm(c *Candidate)
+	// Decline tells the lobby that the candidate doesn't want its match
+	Decline(c *Candidate)
+	// Pong passes the answer of the candidate to a ping of the lobby
+	Pong(c *Candidate, rtt time.Duration)
+}
+
+// Lobby pairs the parties of candidates into games. It is an actor: the
+// queue and the parties are only accessed by the goroutine running run,
+// everyone else changes them by sending messages, and the candidates are
+// probed in other goroutines.
 type Lobby struct {
-	activeCandidates []Candidate
-	register         chan Candidate
+	queue        []*Party
+	enqueue      chan *Candidate
+	join         chan partyRequest
+	queueRequest chan *Candidate
+	cancel       chan *Candidate
+	disconnect   chan *Candidate
+	checked      chan healthCheck
+	confirm      chan *Candidate
+	decline      chan *Candidate
+	readyExpired chan *readyCheck
+	hosted       chan hostedGame
+	queued       chan chan []*Candidate
+	// closed when the lobby stops
+	quit         chan struct{}
+	backfillWait time.Duration
+	readyTimeout time.Duration
+	timeout      time.Duration
+	// parties with a code by their codes
+	parties map[string]*Party
+	// parties which are being checked or whose game is being
+	// created, and the reason why they left meanwhile, if they did
+	pending map[*Party]string
+	// parties which have to confirm that they are ready
+	readying map[*Party]*readyCheck
+	// moving average of the time the matched parties waited
+	averageWait time.Duration
+	// creates the game of a confirmed match and returns the base URL of
+	// the server hosting it, empty for this server. It is called outside
+	// of the lobby, as it may have to wait for another server.
+	host func(game lobbyGame, players []matchPlayer) (string, error)
+}
+
+// lobbyGame describes the game of a confirmed match
+type lobbyGame struct {
+	ID       string        `json:"id"`
+	Capacity int           `json:"capacity"`
+	Bots     []Personality `json:"bots,omitempty"`
+	// whether the result updates the ratings, which is only fair without bots
+	Rated bool `json:"rated"`
 }
 
+// matchPlayer is a player of a confirmed match, with the server it is
+// connected to and the round trip time of its connection
+type matchPlayer struct {
+	node string
+	rtt  time.Duration
+}
+
+// hostedGame is the result of creating the game of a confirmed match
+type hostedGame struct {
+	check *readyCheck
+	game  lobbyGame
+	host  string
+	err   error
+}
+
+// partyRequest asks to join the party with the code, or
+// to create a new one when the code is empty
+type partyRequest struct {
+	candidate *Candidate
+	code      string
+	// whether the candidate was in the party before this lobby took
+	// over from another server, and whether it led the party
+	rejoin bool
+	leader bool
+}
+
+// healthCheck is the result of probing the candidates of a match,
+// which is played against the given number of bots
+type healthCheck struct {
+	parties    []*Party
+	candidates []*Candidate
+	connected  []bool
+	latency    []time.Duration
+	bots       int
+}
+
+// readyCheck is a match waiting for its candidates to confirm
+type readyCheck struct {
+	parties   []*Party
+	bots      []Personality
+	confirmed map[*Candidate]bool
+	latency   map[*Candidate]time.Duration
+	// party of a candidate which declined the match
+	declined *Party
+	done     bool
+}
+
+func newLobby(backfillWait, readyTimeout, timeout time.Duration) *Lobby {
+	return &Lobby{
+		enqueue:      make(chan *Candidate),
+		join:         make(chan partyRequest),
+		queueRequest: make(chan *Candidate),
+		cancel:       make(chan *Candidate),
+		disconnect:   make(chan *Candidate),
+		checked:      make(chan healthCheck),
+		confirm:      make(chan *Candidate),
+		decline:      make(chan *Candidate),
+		readyExpired: make(chan *readyCheck),
+		hosted:       make(chan hostedGame),
+		queued:       make(chan chan []*Candidate),
+		quit:         make(chan struct{}),
+		backfillWait: backfillWait,
+		readyTimeout: readyTimeout,
+		timeout:      timeout,
+		parties:      make(map[string]*Party),
+		pending:      make(map[*Party]string),
+		readying:     make(map[*Party]*readyCheck),
+		host:         hostLocally,
+	}
+}
+
+// initLobby starts the lobby of this server, or joins the lobby
+// shared with the other servers of the cluster
 func initLobby() {
-	if lobby == nil {
-		lobby = &Lobby{
-			make([]Candidate, 0),
-			make(chan Candidate),
-		}
+	if cluster != nil {
+		relay := newLobbyRelay(cluster)
+		go relay.run()
+		go relay.watch()
+		lobby = relay
+		return
 	}
-	go lobby.run()
+	l := newLobby(*botBackfillWait, *readyTimeout, *queueTimeout)
+	go l.run()
+	lobby = l
+}
+
+// Enqueue adds the candidate alone at the end of the queue
+func (l *Lobby) Enqueue(c *Candidate) {
+	l.enqueue <- c
+}
+
+// JoinParty adds the candidate to the party with the code, an empty
+// code creates a new party led by the candidate
+func (l *Lobby) JoinParty(c *Candidate, code string) {
+	l.join <- partyRequest{candidate: c, code: code}
+}
+
+// rejoinParty puts the candidate back into its party
+// after this lobby took over from another server
+func (l *Lobby) rejoinParty(c *Candidate, code string, leader bool) {
+	l.join <- partyRequest{candidate: c, code: code, rejoin: true, leader: leader}
+}
+
+// Queue adds the party led by the candidate at the end of the queue
+func (l *Lobby) Queue(c *Candidate) {
+	l.queueRequest <- c
+}
+
+// Cancel removes the party of the candidate from the queue, a
+// candidate which came alone is sent away
+func (l *Lobby) Cancel(c *Candidate) {
+	l.cancel <- c
+}
+
+// Disconnect removes the candidate whose connection is lost,
+// its party leaves the queue
+func (l *Lobby) Disconnect(c *Candidate) {
+	l.disconnect <- c
+}
+
+// Confirm tells the lobby that the candidate is ready for its match
+func (l *Lobby) Confirm(c *Candidate) {
+	l.confirm <- c
+}
+
+// Decline tells the lobby that the candidate doesn't want its match
+func (l *Lobby) Decline(c *Candidate) {
+	l.decline <- c
+}
+
+// Pong passes the answer to the health check waiting for it,
+// without going through the lobby which is waiting for the check
+func (l *Lobby) Pong(c *Candidate, rtt time.Duration) {
+	c.answer(rtt)
+}
+
+// Queued returns the waiting candidates, the members of the
+// longest waiting party first
+func (l *Lobby) Queued() []*Candidate {
+	reply := make(chan []*Candidate)
+	l.queued <- reply
+	return <-reply
+}
+
+// stop stops the lobby, its candidates are left as they are
+func (l *Lobby) stop() {
+	close(l.quit)
 }
 
 func (l *Lobby) run() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
 	for {
 		select {
-		case candidate := <-l.register:
-			{
-				l.activeCandidates = append(l.activeCandidates, candidate)
-				go l.tryToStart()
+		case <-l.quit:
+			return
+		case c := <-l.enqueue:
+			l.enqueueParty(newParty("", c), time.Now())
+		case req := <-l.join:
+			switch {
+			case req.rejoin:
+				l.restoreParty(req.candidate, req.code, req.leader)
+			case req.code == "":
+				l.createParty(req.candidate)
+			default:
+				l.joinParty(req.candidate, req.code)
+			}
+		case c := <-l.queueRequest:
+			l.queueParty(c)
+		case c := <-l.cancel:
+			l.cancelCandidate(c)
+		case c := <-l.disconnect:
+			l.disconnectCandidate(c)
+		case check := <-l.checked:
+			l.start(check)
+		case c := <-l.confirm:
+			l.confirmCandidate(c)
+		case c := <-l.decline:
+			l.declineCandidate(c)
+		case rc := <-l.readyExpired:
+			if !rc.done {
+				l.failReadyCheck(rc, nil)
+				l.match(time.Now())
+			}
+		case h := <-l.hosted:
+			l.matched(h)
+		case reply := <-l.queued:
+			var queued []*Candidate
+			for _, p := range l.queue {
+				queued = append(queued, p.members...)
 			}
+			reply <- queued
+		case now := <-ticker.C:
+			l.expire(now)
+			// the allowed rating ranges widen while the candidates wait
+			l.match(now)
+			if l.backfillWait > 0 {
+				l.backfill(now)
+			}
+			l.notifyQueued(now)
 		}
 	}
 }
 
-func (l *Lobby) tryToStart() {
-	if len(l.activeCandidates) >= 2 {
-		cand1, err := l.getReadyCandidate()
-		if err != nil {
-			return
+// enqueueParty adds the party at the end of the queue
+func (l *Lobby) enqueueParty(p *Party, now time.Time) {
+	p.searching = true
+	p.joinedAt = now
+	l.queue = append(l.queue, p)
+	p.broadcastState()
+	l.match(now)
+	l.notifyQueued(now)
+}
+
+// cancelCandidate takes the party of the candidate out of the queue,
+// only the leader can do that for a party with friends
+func (l *Lobby) cancelCandidate(c *Candidate) {
+	p := c.party
+	if p == nil || !p.searching {
+		return
+	}
+	if p.code != "" && c != p.leader() {
+		c.Notify(protocol.Error{Error: "Only the leader can take the party out of the queue"})
+		return
+	}
+	l.stopSearching(p, protocol.ReasonCancelled)
+}
+
+// disconnectCandidate removes the candidate which lost its connection
+func (l *Lobby) disconnectCandidate(c *Candidate) {
+	p := c.party
+	switch {
+	case p == nil:
+	case p.code == "":
+		l.stopSearching(p, protocol.ReasonCancelled)
+	default:
+		l.leaveParty(c)
+	}
+}
+
+// stopSearching takes the party out of the queue, parties which are
+// being checked are left out once the check is done
+func (l *Lobby) stopSearching(p *Party, reason string) {
+	if _, ok := l.pending[p]; ok {
+		l.pending[p] = reason
+		return
+	}
+	if rc := l.readying[p]; rc != nil {
+		l.failReadyCheck(rc, p)
+		l.sendAway(p, reason)
+		l.match(time.Now())
+		return
+	}
+	if l.remove(p) {
+		l.sendAway(p, reason)
+	}
+}
+
+// sendAway tells the members that the party left the queue without a
+// game. Candidates which came alone are done with the lobby, parties
+// stay together and the leader can queue them again.
+func (l *Lobby) sendAway(p *Party, reason string) {
+	p.searching = false
+	msg := protocol.Dequeued{Reason: reason}
+	if p.code == "" {
+		for _, c := range p.members {
+			c.party = nil
+			c.Leave(msg)
+		}
+		return
+	}
+	p.send(msg)
+	p.broadcastState()
+}
+
+// expire sends the parties which have waited for longer than the timeout away
+func (l *Lobby) expire(now time.Time) {
+	for len(l.queue) > 0 && now.Sub(l.queue[0].joinedAt) >= l.timeout {
+		p := l.queue[0]
+		l.remove(p)
+		log.Printf("Party of %d timed out after %s in the lobby", p.size(), l.timeout)
+		l.sendAway(p, protocol.ReasonTimeout)
+	}
+}
+
+// notifyQueued tells each waiting party its position and estimated wait
+func (l *Lobby) notifyQueued(now time.Time) {
+	for i, p := range l.queue {
+		wait := l.estimatedWait(now.Sub(p.joinedAt))
+		if penalty := p.penaltyUntil.Sub(now); penalty > wait {
+			wait = penalty
+		}
+		p.notify(protocol.Queued{Position: i + 1, EstimatedWait: int(math.Ceil(wait.Seconds()))})
+	}
+}
+
+// estimatedWait returns the remaining wait of a candidate which has waited
+// for the given time, based on how long the last matched candidates waited
+func (l *Lobby) estimatedWait(waited time.Duration) time.Duration {
+	wait := l.averageWait
+	if wait == 0 || (l.backfillWait > 0 && l.backfillWait < wait) {
+		wait = l.backfillWait
+	}
+	if wait == 0 || l.timeout < wait {
+		wait = l.timeout
+	}
+	if waited >= wait {
+		return 0
+	}
+	return wait - waited
+}
+
+// matchRange returns the rating difference a candidate accepts
+// for an opponent after waiting for the given time
+func matchRange(waited time.Duration) float64 {
+	r := initialMatchRange + matchRangeGrowth*waited.Seconds()
+	if r > maxMatchRange {
+		return maxMatchRange
+	}
+	return r
+}
+
+// findOpponent returns the index of the party with the closest average
+// rating to the party at index i, which both of them accept and which fits
+// into the same game, or -1
+func (l *Lobby) findOpponent(i int, now time.Time) int {
+	cand := l.queue[i]
+	if now.Before(cand.penaltyUntil) {
+		return -1
+	}
+	best, bestDiff := -1, 0.0
+	for j, other := range l.queue {
+		if j == i || cand.size()+other.size() > maxPlayers || cand.shares(other) || now.Before(other.penaltyUntil) {
+			continue
+		}
+		diff := math.Abs(cand.rating() - other.rating())
+		allowed := math.Max(matchRange(now.Sub(cand.joinedAt)), matchRange(now.Sub(other.joinedAt)))
+		if diff <= allowed && (best < 0 || diff < bestDiff) {
+			best, bestDiff = j, diff
+		}
+	}
+	return best
+}
+
+// match takes each party, starting with the longest waiting one,
+// out of the queue together with the opponents with the most similar
+// rating and checks whether all of them are still there
+func (l *Lobby) match(now time.Time) {
+	for i := 0; i < len(l.queue); {
+		j := l.findOpponent(i, now)
+		if j < 0 {
+			i++
+			continue
 		}
-		cand2, err := l.getReadyCandidate()
-		if err != nil {
+		cand1, cand2 := l.queue[i], l.queue[j]
+		l.remove(cand1)
+		l.remove(cand2)
+		l.check([]*Party{cand1, cand2}, 0)
+	}
+}
+
+// backfill takes the parties which have waited for longer
+// than backfillWait out of the queue for a game with bots
+func (l *Lobby) backfill(now time.Time) {
+	for i := 0; i < len(l.queue); {
+		cand := l.queue[i]
+		if now.Sub(cand.joinedAt) < l.backfillWait {
 			return
 		}
+		if now.Before(cand.penaltyUntil) {
+			i++
+			continue
+		}
+		l.remove(cand)
+		l.check([]*Party{cand}, lobbyGamePlayers-1)
+	}
+}
 
-		gameID, err := createGame()
-		if err != nil {
-			log.Printf("Error while starting game %s", err.Error())
+// check probes the members of the parties without blocking
+// the lobby, the result arrives as a message
+func (l *Lobby) check(parties []*Party, bots int) {
+	var candidates []*Candidate
+	for _, p := range parties {
+		l.pending[p] = ""
+		candidates = append(candidates, p.members...)
+	}
+	go func() {
+		connected := make([]bool, len(candidates))
+		latency := make([]time.Duration, len(candidates))
+		for i, c := range candidates {
+			latency[i], connected[i] = c.IsConnected()
+		}
+		select {
+		case l.checked <- healthCheck{parties, candidates, connected, latency, bots}:
+		case <-l.quit:
+		}
+	}()
+}
+
+// start asks the checked candidates to confirm the match, or puts
+// the parties which are complete back into the queue when someone has left
+func (l *Lobby) start(check healthCheck) {
+	connected := make(map[*Candidate]bool, len(check.candidates))
+	latency := make(map[*Candidate]time.Duration, len(check.candidates))
+	for i, c := range check.candidates {
+		connected[c] = check.connected[i]
+		latency[c] = check.latency[i]
+	}
+	var complete []*Party
+	for _, p := range check.parties {
+		reason := l.pending[p]
+		delete(l.pending, p)
+		if reason != "" {
+			l.sendAway(p, reason)
+			continue
+		}
+		var lost []*Candidate
+		for _, c := range p.members {
+			if !connected[c] {
+				lost = append(lost, c)
+			}
+		}
+		if len(lost) == 0 {
+			complete = append(complete, p)
+			continue
+		}
+		// candidates which don't answer are closed, a party goes on without them
+		for _, c := range lost {
+			if p.code != "" {
+				p.remove(c)
+			}
+			c.party = nil
+			c.Close()
+		}
+		if p.code != "" {
+			if p.size() == 0 {
+				delete(l.parties, p.code)
+			}
+			l.sendAway(p, protocol.ReasonPartyChanged)
 		}
-		cand1.Redirect([]byte(gameID))
-		cand2.Redirect([]byte(gameID))
 	}
+	if len(complete) < len(check.parties) {
+		for _, p := range complete {
+			l.requeue(p)
+		}
+		return
+	}
+
+	rc := &readyCheck{
+		parties:   check.parties,
+		bots:      make([]Personality, check.bots),
+		confirmed: make(map[*Candidate]bool),
+		latency:   latency,
+	}
+	for i := range rc.bots {
+		rc.bots[i] = randomPersonality()
+	}
+	for _, p := range rc.parties {
+		l.readying[p] = rc
+		for _, c := range p.members {
+			c.Send(protocol.ReadyCheck{Timeout: int(l.readyTimeout.Seconds()), Opponents: rc.opponents(c)})
+		}
+	}
+	time.AfterFunc(l.readyTimeout, func() {
+		select {
+		case l.readyExpired <- rc:
+		case <-l.quit:
+		}
+	})
+}
+
+// confirmCandidate records the confirmation of the candidate,
+// the game is created once everyone has confirmed
+func (l *Lobby) confirmCandidate(c *Candidate) {
+	if c.party == nil {
+		return
+	}
+	rc := l.readying[c.party]
+	if rc == nil {
+		return
+	}
+	rc.confirmed[c] = true
+	if len(rc.confirmed) < rc.players() {
+		return
+	}
+	rc.done = true
+	for _, p := range rc.parties {
+		delete(l.readying, p)
+	}
+
+	game := lobbyGame{ID: randToken(), Capacity: rc.players() + len(rc.bots), Bots: rc.bots, Rated: len(rc.bots) == 0}
+	var players []matchPlayer
+	for _, p := range rc.parties {
+		for _, c := range p.members {
+			players = append(players, matchPlayer{node: c.node, rtt: rc.latency[c]})
+		}
+	}
+	for _, p := range rc.parties {
+		l.pending[p] = ""
+	}
+	go func() {
+		host, err := l.host(game, players)
+		select {
+		case l.hosted <- hostedGame{rc, game, host, err}:
+		case <-l.quit:
+		}
+	}()
 }
 
-func (l *Lobby) getReadyCandidate() (*Candidate, error) {
-	found := false
-	var cand Candidate
-	for !found {
-		if len(l.activeCandidates) == 0 {
-			return nil, errors.New("There are no active players to join")
+// matched sends the candidates to the game created for their match. When
+// the game couldn't be created, the parties keep their places and are
+// matched again, those which left meanwhile are sent away.
+func (l *Lobby) matched(h hostedGame) {
+	rc := h.check
+	if h.err != nil {
+		log.Printf("Could not start game %s, %v", h.game.ID, h.err)
+		for _, p := range rc.parties {
+			reason := l.pending[p]
+			delete(l.pending, p)
+			if reason != "" {
+				l.sendAway(p, reason)
+				continue
+			}
+			l.requeue(p)
 		}
-		cand = l.activeCandidates[0]
-		l.activeCandidates = l.activeCandidates[1:]
-		found = cand.IsConnected()
+		l.notifyQueued(time.Now())
+		return
+	}
+	if len(rc.bots) > 0 {
+		log.Printf("Filled game %s with %d bots", h.game.ID, len(rc.bots))
+	} else {
+		parties := make([]string, len(rc.parties))
+		for i, p := range rc.parties {
+			parties[i] = fmt.Sprintf("%.0f", p.rating())
+			if p.size() > 1 {
+				parties[i] += fmt.Sprintf(" for a party of %d", p.size())
+			}
+		}
+		log.Printf("Matched ratings %s in game %s", strings.Join(parties, " and "), h.game.ID)
+	}
+
+	// the game is there, the members who are still
+	// connected go to it even if their party changed
+	now := time.Now()
+	for _, p := range rc.parties {
+		delete(l.pending, p)
+		l.recordWait(now.Sub(p.joinedAt))
+		for _, c := range p.members {
+			c.Leave(protocol.Match{Game: h.game.ID, Host: h.host, Opponents: rc.opponents(c)})
+		}
+	}
+	for _, p := range rc.parties {
+		l.disband(p)
+	}
+}
+
+// declineCandidate fails the ready check of the candidate right away,
+// its party is penalised and the others keep their places
+func (l *Lobby) declineCandidate(c *Candidate) {
+	if c.party == nil {
+		return
+	}
+	rc := l.readying[c.party]
+	if rc == nil {
+		return
+	}
+	rc.declined = c.party
+	l.failReadyCheck(rc, nil)
+	l.match(time.Now())
+}
+
+// hostLocally creates the game of a match on this server
+func hostLocally(spec lobbyGame, players []matchPlayer) (string, error) {
+	game, err := createGameWithID(spec.ID, spec.Capacity)
+	if err != nil {
+		return "", err
+	}
+	game.rated = spec.Rated
+	connectBots(game, spec.Bots)
+	return "", nil
+}
+
+// failReadyCheck puts the parties of a failed ready check back into
+// the queue. The ones who confirmed keep their place, the others go to
+// the end of the queue and can't be matched for a while. When someone
+// declined, only that party is penalised. The party which left, if any,
+// is left out.
+func (l *Lobby) failReadyCheck(rc *readyCheck, left *Party) {
+	rc.done = true
+	now := time.Now()
+	for _, p := range rc.parties {
+		delete(l.readying, p)
+		switch {
+		case p == left:
+		case !rc.missed(p):
+			l.requeue(p)
+		default:
+			log.Printf("Party of %d missed the ready check, penalised for %s", p.size(), readyPenalty)
+			p.joinedAt = now
+			p.penaltyUntil = now.Add(readyPenalty)
+			l.queue = append(l.queue, p)
+		}
+	}
+	l.notifyQueued(now)
+}
+
+// players returns the number of candidates in the match
+func (rc *readyCheck) players() int {
+	n := 0
+	for _, p := range rc.parties {
+		n += p.size()
+	}
+	return n
+}
+
+// allConfirmed tells whether all members of the party confirmed
+func (rc *readyCheck) allConfirmed(p *Party) bool {
+	for _, c := range p.members {
+		if !rc.confirmed[c] {
+			return false
+		}
+	}
+	return true
+}
+
+// missed tells whether the party failed the ready check, the party which
+// declined did, otherwise the parties which didn't confirm in time
+func (rc *readyCheck) missed(p *Party) bool {
+	if rc.declined != nil {
+		return p == rc.declined
+	}
+	return !rc.allConfirmed(p)
+}
+
+// opponents returns the opponents of the candidate in the match
+func (rc *readyCheck) opponents(c *Candidate) []protocol.Opponent {
+	opponents := make([]protocol.Opponent, 0, rc.players()+len(rc.bots)-1)
+	for _, p := range rc.parties {
+		for _, other := range p.members {
+			if other != c {
+				opponents = append(opponents, protocol.Opponent{
+					PlayerProfile: other.profile(),
+					Rating:        int(math.Round(other.rating.Rating)),
+					Party:         p == c.party,
+				})
+			}
+		}
+	}
+	for _, p := range rc.bots {
+		opponents = append(opponents, protocol.Opponent{Bot: true, Personality: string(p)})
+	}
+	return opponents
+}
+
+// recordWait adds the wait of a matched party to the average wait
+func (l *Lobby) recordWait(wait time.Duration) {
+	if l.averageWait == 0 {
+		l.averageWait = wait
+		return
+	}
+	l.averageWait = (4*l.averageWait + wait) / 5
+}
+
+// remove takes the party out of the queue, it returns
+// whether the party was waiting
+func (l *Lobby) remove(p *Party) bool {
+	for i, cand := range l.queue {
+		if cand == p {
+			l.queue = append(l.queue[:i], l.queue[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// requeue puts the party back into the queue, keeping
+// its place by the time it joined
+func (l *Lobby) requeue(p *Party) {
+	i := 0
+	for i < len(l.queue) && !l.queue[i].joinedAt.After(p.joinedAt) {
+		i++
 	}
-	return &cand, nil
+	l.queue = append(l.queue, nil)
+	copy(l.queue[i+1:], l.queue[i:])
+	l.queue[i] = p
 }
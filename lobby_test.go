@@ -0,0 +1,352 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lazareviczoran/blaster-twister/protocol"
+)
+
+// testClient is a candidate without a connection, which answers the
+// pings of the lobby and passes the other messages to the test. The
+// messages passed on by the relay are decoded, so they are pointers.
+type testClient struct {
+	*Candidate
+	messages chan protocol.Message
+	// whether the client confirms the ready checks by itself
+	confirm bool
+}
+
+func newTestClient(l Matchmaker, confirm bool) *testClient {
+	c := &Candidate{
+		send:         make(chan protocol.Message, 8),
+		receive:      make(chan time.Duration, 1),
+		done:         make(chan struct{}),
+		lobby:        l,
+		session:      randToken(),
+		rating:       Rating{Rating: initialRating, Deviation: initialDeviation},
+		checkTimeout: candidateCheckTimeout,
+		key:          randToken(),
+	}
+	tc := &testClient{Candidate: c, messages: make(chan protocol.Message, 32), confirm: confirm}
+	go tc.read()
+	return tc
+}
+
+func (tc *testClient) read() {
+	for {
+		select {
+		case msg := <-tc.send:
+			switch msg.(type) {
+			case protocol.Ping, *protocol.Ping:
+				tc.lobby.Pong(tc.Candidate, time.Millisecond)
+				continue
+			case protocol.Queued, *protocol.Queued:
+				continue
+			case protocol.ReadyCheck, *protocol.ReadyCheck:
+				if tc.confirm {
+					tc.lobby.Confirm(tc.Candidate)
+				}
+			case nil:
+				close(tc.messages)
+				return
+			}
+			tc.messages <- msg
+		case <-tc.done:
+			return
+		}
+	}
+}
+
+// disconnect drops the client like a lost connection
+func (tc *testClient) disconnect() {
+	close(tc.done)
+	tc.lobby.Disconnect(tc.Candidate)
+}
+
+// next returns the next message of the lobby, nil once the connection is closed
+func (tc *testClient) next(t *testing.T) protocol.Message {
+	t.Helper()
+	select {
+	case msg := <-tc.messages:
+		return msg
+	case <-time.After(3 * time.Second):
+		t.Fatal("No message from the lobby")
+		return nil
+	}
+}
+
+func (tc *testClient) expectParty(t *testing.T, members int, queued bool) protocol.Party {
+	t.Helper()
+	msg := tc.next(t)
+	party, ok := msg.(protocol.Party)
+	if !ok {
+		t.Fatalf("Got %#v, want the party", msg)
+	}
+	if len(party.Members) != members || party.Queued != queued {
+		t.Fatalf("Got a party of %d queued %v, want %d queued %v", len(party.Members), party.Queued, members, queued)
+	}
+	return party
+}
+
+// hostRecorder stands in for the hosting of the games
+type hostRecorder struct {
+	mu    sync.Mutex
+	games []lobbyGame
+	// number of games which can't be created
+	failures int
+	// blocks the hosting until it is closed, like a slow server
+	wait chan struct{}
+}
+
+func (h *hostRecorder) host(game lobbyGame, players []matchPlayer) (string, error) {
+	if h.wait != nil {
+		<-h.wait
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.failures > 0 {
+		h.failures--
+		return "", errors.New("No server available")
+	}
+	h.games = append(h.games, game)
+	return "", nil
+}
+
+func (h *hostRecorder) hosted() []lobbyGame {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]lobbyGame(nil), h.games...)
+}
+
+func startTestLobby(readyTimeout time.Duration) (*Lobby, *hostRecorder) {
+	l := newLobby(0, readyTimeout, time.Minute)
+	h := &hostRecorder{}
+	l.host = h.host
+	go l.run()
+	return l, h
+}
+
+func TestLobbyMatchesCandidates(t *testing.T) {
+	l, h := startTestLobby(time.Second)
+	defer l.stop()
+	a, b := newTestClient(l, true), newTestClient(l, true)
+	l.Enqueue(a.Candidate)
+	l.Enqueue(b.Candidate)
+
+	var games []string
+	for _, tc := range []*testClient{a, b} {
+		if msg, ok := tc.next(t).(protocol.ReadyCheck); !ok || len(msg.Opponents) != 1 {
+			t.Fatalf("Got %#v, want a ready check with an opponent", msg)
+		}
+		match, ok := tc.next(t).(protocol.Match)
+		if !ok {
+			t.Fatalf("Got %#v, want the match", match)
+		}
+		games = append(games, match.Game)
+		if msg := tc.next(t); msg != nil {
+			t.Fatalf("Got %#v, want the connection closed", msg)
+		}
+	}
+	if games[0] != games[1] {
+		t.Errorf("Matched into games %s and %s", games[0], games[1])
+	}
+	hosted := h.hosted()
+	if len(hosted) != 1 || hosted[0].ID != games[0] || hosted[0].Capacity != 2 || !hosted[0].Rated {
+		t.Errorf("Hosted %+v, want the rated game %s for 2", hosted, games[0])
+	}
+	if queued := l.Queued(); len(queued) != 0 {
+		t.Errorf("%d candidates left in the queue", len(queued))
+	}
+}
+
+func TestLobbyReadyCheckTimeout(t *testing.T) {
+	l, h := startTestLobby(300 * time.Millisecond)
+	defer l.stop()
+	a, b := newTestClient(l, true), newTestClient(l, false)
+	l.Enqueue(a.Candidate)
+	l.Enqueue(b.Candidate)
+	for _, tc := range []*testClient{a, b} {
+		if msg, ok := tc.next(t).(protocol.ReadyCheck); !ok {
+			t.Fatalf("Got %#v, want a ready check", msg)
+		}
+	}
+
+	time.Sleep(500 * time.Millisecond)
+	// both wait again, the candidate which missed the check behind
+	queued := l.Queued()
+	if len(queued) != 2 || queued[0] != a.Candidate || queued[1] != b.Candidate {
+		t.Fatalf("Queued %v, want the candidate which confirmed first", queued)
+	}
+	if hosted := h.hosted(); len(hosted) != 0 {
+		t.Errorf("Hosted %+v without a confirmed match", hosted)
+	}
+}
+
+func TestLobbyReadyCheckDeclined(t *testing.T) {
+	l, h := startTestLobby(time.Minute)
+	defer l.stop()
+	a, b := newTestClient(l, false), newTestClient(l, false)
+	l.Enqueue(a.Candidate)
+	l.Enqueue(b.Candidate)
+	for _, tc := range []*testClient{a, b} {
+		if msg, ok := tc.next(t).(protocol.ReadyCheck); !ok {
+			t.Fatalf("Got %#v, want a ready check", msg)
+		}
+	}
+
+	// the check fails without waiting for the timeout, the
+	// candidate which declined waits behind the other one
+	l.Decline(a.Candidate)
+	queued := l.Queued()
+	if len(queued) != 2 || queued[0] != b.Candidate || queued[1] != a.Candidate {
+		t.Fatalf("Queued %v, want the candidate which declined last", queued)
+	}
+	if hosted := h.hosted(); len(hosted) != 0 {
+		t.Errorf("Hosted %+v for a declined match", hosted)
+	}
+}
+
+func TestLobbyRequeuesWhenHostingFails(t *testing.T) {
+	l, h := startTestLobby(time.Second)
+	defer l.stop()
+	h.failures = 1
+	a, b := newTestClient(l, true), newTestClient(l, true)
+	l.Enqueue(a.Candidate)
+	l.Enqueue(b.Candidate)
+	for _, tc := range []*testClient{a, b} {
+		if msg, ok := tc.next(t).(protocol.ReadyCheck); !ok {
+			t.Fatalf("Got %#v, want a ready check", msg)
+		}
+	}
+
+	// the candidates are matched again once the game can be created
+	for _, tc := range []*testClient{a, b} {
+		if msg, ok := tc.next(t).(protocol.ReadyCheck); !ok {
+			t.Fatalf("Got %#v, want another ready check", msg)
+		}
+		if msg, ok := tc.next(t).(protocol.Match); !ok {
+			t.Fatalf("Got %#v, want the match", msg)
+		}
+	}
+	if hosted := h.hosted(); len(hosted) != 1 {
+		t.Errorf("Hosted %d games, want 1", len(hosted))
+	}
+}
+
+func TestLobbyKeepsRunningWhileHosting(t *testing.T) {
+	l, h := startTestLobby(time.Second)
+	defer l.stop()
+	h.wait = make(chan struct{})
+	a, b := newTestClient(l, true), newTestClient(l, true)
+	l.Enqueue(a.Candidate)
+	l.Enqueue(b.Candidate)
+	for _, tc := range []*testClient{a, b} {
+		if msg, ok := tc.next(t).(protocol.ReadyCheck); !ok {
+			t.Fatalf("Got %#v, want a ready check", msg)
+		}
+	}
+
+	// others can queue while the game is created
+	c := newTestClient(l, true)
+	l.Enqueue(c.Candidate)
+	if queued := l.Queued(); len(queued) != 1 || queued[0] != c.Candidate {
+		t.Fatalf("Queued %v, want the new candidate", queued)
+	}
+	close(h.wait)
+	for _, tc := range []*testClient{a, b} {
+		if msg, ok := tc.next(t).(protocol.Match); !ok {
+			t.Fatalf("Got %#v, want the match", msg)
+		}
+	}
+}
+
+func TestLobbyParty(t *testing.T) {
+	l, h := startTestLobby(time.Second)
+	defer l.stop()
+	leader, friend, solo := newTestClient(l, true), newTestClient(l, true), newTestClient(l, true)
+	l.JoinParty(leader.Candidate, "")
+	party := leader.expectParty(t, 1, false)
+	if party.Code == "" || party.You != party.Members[0].ID || !party.Members[0].Leader {
+		t.Fatalf("Got %+v, want a new party led by its creator", party)
+	}
+
+	l.JoinParty(friend.Candidate, party.Code)
+	leader.expectParty(t, 2, false)
+	friend.expectParty(t, 2, false)
+	l.Queue(friend.Candidate)
+	if msg, ok := friend.next(t).(protocol.Error); !ok {
+		t.Fatalf("Got %#v, want an error for a member queueing the party", msg)
+	}
+	l.Queue(leader.Candidate)
+	leader.expectParty(t, 2, true)
+	friend.expectParty(t, 2, true)
+
+	late := newTestClient(l, true)
+	l.JoinParty(late.Candidate, party.Code)
+	if msg, ok := late.next(t).(protocol.Error); !ok {
+		t.Fatalf("Got %#v, want an error for joining a queued party", msg)
+	}
+
+	l.Enqueue(solo.Candidate)
+	for _, tc := range []*testClient{leader, friend, solo} {
+		msg, ok := tc.next(t).(protocol.ReadyCheck)
+		if !ok || len(msg.Opponents) != 2 {
+			t.Fatalf("Got %#v, want a ready check with 2 opponents", msg)
+		}
+	}
+	for _, tc := range []*testClient{leader, friend, solo} {
+		if msg, ok := tc.next(t).(protocol.Match); !ok {
+			t.Fatalf("Got %#v, want the match", msg)
+		}
+	}
+	if hosted := h.hosted(); len(hosted) != 1 || hosted[0].Capacity != 3 {
+		t.Errorf("Hosted %+v, want a game for 3", hosted)
+	}
+}
+
+func TestLobbyPartyMemberLeaves(t *testing.T) {
+	l, _ := startTestLobby(time.Second)
+	defer l.stop()
+	leader, friend := newTestClient(l, true), newTestClient(l, true)
+	l.JoinParty(leader.Candidate, "")
+	party := leader.expectParty(t, 1, false)
+	l.JoinParty(friend.Candidate, party.Code)
+	leader.expectParty(t, 2, false)
+	friend.expectParty(t, 2, false)
+	l.Queue(leader.Candidate)
+	leader.expectParty(t, 2, true)
+
+	friend.disconnect()
+	msg, ok := leader.next(t).(protocol.Dequeued)
+	if !ok || msg.Reason != protocol.ReasonPartyChanged {
+		t.Fatalf("Got %#v, want the party out of the queue", msg)
+	}
+	leader.expectParty(t, 1, false)
+}
+
+func TestCandidateSendDoesNotBlock(t *testing.T) {
+	c := &Candidate{send: make(chan protocol.Message, 2), done: make(chan struct{})}
+	sent := make(chan bool)
+	go func() {
+		for i := 0; i < 3; i++ {
+			sent <- c.Send(protocol.Ping{})
+		}
+	}()
+	for i, want := range []bool{true, true, false} {
+		select {
+		case got := <-sent:
+			if got != want {
+				t.Errorf("Send %d returned %v, want %v", i, got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Send %d blocked on a full queue", i)
+		}
+	}
+	close(c.done)
+	if c.Send(protocol.Ping{}) {
+		t.Error("Queued a message after the connection was lost")
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"time"
 )
 
+var train = flag.Bool("train", false, "run the training environment over stdin/stdout instead of the web server")
+
 func main() {
 	HOST := ""
 	if os.Getenv("GO_ENV") == "development" {
@@ -18,10 +20,21 @@ func main() {
 	if PORT == "" {
 		PORT = "8080"
 	}
+	flag.Parse()
+	if *train {
+		if err := serveEnv(os.Stdin, os.Stdout); err != nil {
+			log.Fatal("serveEnv: ", err)
+		}
+		return
+	}
+
 	rand.Seed(time.Now().UnixNano())
+	initRatings()
+	if err := initCluster(); err != nil {
+		log.Fatal("initCluster: ", err)
+	}
 	initLobby()
 
-	flag.Parse()
 	router := createRouter()
 	http.Handle("/src/", http.StripPrefix("/src/", http.FileServer(http.Dir("./dist"))))
 	http.Handle("/css/", http.StripPrefix("/css/", http.FileServer(http.Dir("./frontend/css"))))
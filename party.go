@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/lazareviczoran/blaster-twister/protocol"
+)
+
+// Largest party, so that there is always a seat left for an opponent
+const maxPartySize = maxPlayers - 1
+
+// Party is a group of candidates which the lobby queues and matches as a
+// single entry needing a seat for each member, so they end up in the same
+// game. A candidate which comes alone is a party of one without a code.
+// Parties are only accessed by the goroutine running the lobby.
+type Party struct {
+	// code the friends join with, empty for a single candidate
+	code string
+	// the first member leads the party and controls the queue
+	members []*Candidate
+	nextID  int
+	// whether the party is waiting for a game
+	searching bool
+	joinedAt  time.Time
+	// the party can't be matched before this time
+	penaltyUntil time.Time
+}
+
+func newParty(code string, leader *Candidate) *Party {
+	p := &Party{code: code}
+	p.add(leader)
+	return p
+}
+
+func (p *Party) add(c *Candidate) {
+	c.party = p
+	c.id = p.nextID
+	p.nextID++
+	p.members = append(p.members, c)
+}
+
+// remove takes the candidate out of the party, the longest
+// waiting member becomes the leader when the leader leaves
+func (p *Party) remove(c *Candidate) {
+	for i, member := range p.members {
+		if member == c {
+			p.members = append(p.members[:i], p.members[i+1:]...)
+			break
+		}
+	}
+	c.party = nil
+}
+
+func (p *Party) leader() *Candidate {
+	if len(p.members) == 0 {
+		return nil
+	}
+	return p.members[0]
+}
+
+func (p *Party) size() int {
+	return len(p.members)
+}
+
+// rating is the average rating of the members
+func (p *Party) rating() float64 {
+	sum := 0.0
+	for _, c := range p.members {
+		sum += c.rating.Rating
+	}
+	return sum / float64(len(p.members))
+}
+
+// shares tells whether a session is in both parties, a player
+// with several tabs must not be matched against itself
+func (p *Party) shares(other *Party) bool {
+	for _, c := range p.members {
+		for _, o := range other.members {
+			if c.session != "" && c.session == o.session {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (p *Party) link() string {
+	return fmt.Sprintf("/join?party=%s", p.code)
+}
+
+// send queues the message for all members
+func (p *Party) send(msg protocol.Message) {
+	for _, c := range p.members {
+		c.Send(msg)
+	}
+}
+
+// notify queues the update for all members which aren't lagging behind
+func (p *Party) notify(msg protocol.Message) {
+	for _, c := range p.members {
+		c.Notify(msg)
+	}
+}
+
+// broadcastState sends the state of a party with a code to its members
+func (p *Party) broadcastState() {
+	if p.code == "" {
+		return
+	}
+	members := make([]protocol.PartyMember, len(p.members))
+	for i, c := range p.members {
+		members[i] = protocol.PartyMember{
+			ID:            c.id,
+			PlayerProfile: c.profile(),
+			Rating:        int(math.Round(c.rating.Rating)),
+			Leader:        i == 0,
+		}
+	}
+	for _, c := range p.members {
+		c.Send(protocol.Party{Code: p.code, Link: p.link(), You: c.id, Members: members, Queued: p.searching})
+	}
+}
+
+// createParty makes the candidate the leader of a new party
+func (l *Lobby) createParty(c *Candidate) {
+	code := newRoomCode()
+	for l.parties[code] != nil {
+		code = newRoomCode()
+	}
+	p := newParty(code, c)
+	l.parties[code] = p
+	log.Printf("Created party %s", code)
+	p.broadcastState()
+}
+
+// joinParty adds the candidate to the party with the code,
+// which can't change while it is waiting for a game
+func (l *Lobby) joinParty(c *Candidate, code string) {
+	p := l.parties[code]
+	switch {
+	case p == nil:
+		c.Leave(protocol.Error{Error: "The party doesn't exist"})
+	case p.searching:
+		c.Leave(protocol.Error{Error: "The party is already waiting for a game"})
+	case p.size() >= maxPartySize:
+		c.Leave(protocol.Error{Error: "The party is full"})
+	default:
+		p.add(c)
+		p.broadcastState()
+	}
+}
+
+// restoreParty puts the candidate back into the party it was in before
+// another server took over the lobby. The first member which comes back
+// creates the party again with its code, the leader leads it again and
+// can queue it again.
+func (l *Lobby) restoreParty(c *Candidate, code string, leader bool) {
+	p := l.parties[code]
+	switch {
+	case p == nil:
+		p = newParty(code, c)
+		l.parties[code] = p
+		log.Printf("Restored party %s", code)
+	case p.searching || p.size() >= maxPartySize:
+		c.Leave(protocol.Error{Error: "The party could not be restored"})
+		return
+	default:
+		p.add(c)
+		if leader {
+			copy(p.members[1:], p.members[:p.size()-1])
+			p.members[0] = c
+		}
+	}
+	p.broadcastState()
+}
+
+// queueParty puts the party of its leader into the queue
+func (l *Lobby) queueParty(c *Candidate) {
+	p := c.party
+	if p == nil || p.searching {
+		return
+	}
+	if c != p.leader() {
+		c.Notify(protocol.Error{Error: "Only the leader can queue the party"})
+		return
+	}
+	l.enqueueParty(p, time.Now())
+}
+
+// leaveParty removes the disconnected candidate from its party,
+// which leaves the queue as it needs one seat less now
+func (l *Lobby) leaveParty(c *Candidate) {
+	p := c.party
+	p.remove(c)
+	if p.size() == 0 {
+		delete(l.parties, p.code)
+		log.Printf("Closed party %s", p.code)
+	}
+	if p.searching {
+		l.stopSearching(p, protocol.ReasonPartyChanged)
+		return
+	}
+	p.broadcastState()
+}
+
+// disband removes the matched party from the lobby
+func (l *Lobby) disband(p *Party) {
+	p.searching = false
+	for _, c := range p.members {
+		c.party = nil
+	}
+	if p.code != "" {
+		delete(l.parties, p.code)
+	}
+}
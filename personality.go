@@ -0,0 +1,214 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+
+	"github.com/lazareviczoran/blaster-twister/protocol"
+)
+
+// Personality defines the play style of a bot
+type Personality string
+
+// Available bot personalities
+const (
+	// Heads towards the farthest wall
+	PersonalityStandard Personality = "standard"
+	// Predicts the path of the closest opponent and tries to cut in front of it
+	PersonalityAggressive Personality = "aggressive"
+	// Prefers wide open areas and avoids sharp turns
+	PersonalityCautious Personality = "cautious"
+	// Reacts with a delay and makes human-like mistakes
+	PersonalityErratic Personality = "erratic"
+)
+
+var personalities = []Personality{
+	PersonalityStandard,
+	PersonalityAggressive,
+	PersonalityCautious,
+	PersonalityErratic,
+}
+
+const (
+	// Ticks the aggressive bot looks ahead when predicting the opponents path
+	cutOffLookAhead = 30
+
+	// Minimal free distance the aggressive bot keeps in front of it
+	cutOffSafeDistance = 40
+
+	// Penalty of the cautious bot for each degree it has to turn
+	cautiousTurnPenalty = 1
+
+	// Reaction delay of the erratic bot in ticks
+	erraticMinDelay = 3
+	erraticMaxDelay = 8
+
+	// Probability that the erratic bot steers in a wrong direction
+	erraticMistakeRate = 0.1
+)
+
+func randomPersonality() Personality {
+	return personalities[rand.Intn(len(personalities))]
+}
+
+// parsePersonalities parses a comma separated list of personalities,
+// the bots without a specified personality get a random one
+func parsePersonalities(value string, bots int) ([]Personality, error) {
+	result := make([]Personality, bots)
+	var names []string
+	if value != "" {
+		names = strings.Split(value, ",")
+	}
+	if len(names) > bots {
+		return nil, fmt.Errorf("Got %d personalities for %d bots", len(names), bots)
+	}
+	for i := range result {
+		if i >= len(names) {
+			result[i] = randomPersonality()
+			continue
+		}
+		p := Personality(strings.TrimSpace(names[i]))
+		if !p.valid() {
+			return nil, fmt.Errorf("Unknown personality %q", p)
+		}
+		result[i] = p
+	}
+	return result, nil
+}
+
+func (p Personality) valid() bool {
+	for _, personality := range personalities {
+		if p == personality {
+			return true
+		}
+	}
+	return false
+}
+
+// chooseAngle returns the angle the bot wants to head to according to
+// its personality and difficulty, together with the details of the decision
+func (b *Bot) chooseAngle(x0, y0, rotation int) (int, *protocol.BotDecision) {
+	decision := &protocol.BotDecision{
+		Bot:         b.id,
+		Personality: string(b.personality),
+		X:           x0,
+		Y:           y0,
+		Rotation:    rotation,
+		Rays:        b.getDistancesToWalls(x0, y0),
+	}
+	decision.Scores = decision.Rays
+	delay := b.difficulty.ReactionDelay
+	mistakeRate := b.difficulty.MistakeRate
+	switch b.personality {
+	case PersonalityAggressive:
+		decision.Chosen, decision.CutOff = b.findAngleToCutOff(x0, y0, decision.Rays)
+	case PersonalityCautious:
+		decision.Chosen, decision.Scores = findAngleToOpenSpace(decision.Rays, rotation)
+	case PersonalityErratic:
+		decision.Chosen = findAngleToFarthestIntersection(decision.Rays)
+		delay += randomIntFromRange(erraticMinDelay, erraticMaxDelay)
+		mistakeRate += erraticMistakeRate
+	default:
+		decision.Chosen = findAngleToFarthestIntersection(decision.Rays)
+	}
+	angle := decision.Chosen
+	if rand.Float64() < mistakeRate {
+		angle = (angle + 360 + randomIntFromRange(-90, 90)) % 360
+		decision.Mistake = true
+	}
+	decision.Target = b.delayDecision(angle, delay)
+	return decision.Target, decision
+}
+
+// findAngleToCutOff predicts where the closest opponent is heading to
+// and returns the angle to a point in front of it, which can be reached
+// before the opponent gets there, together with that point
+func (b *Bot) findAngleToCutOff(x0, y0 int, distances []int) (int, []int) {
+	opponent := b.closestOpponent(x0, y0)
+	if opponent != nil {
+		x, _ := opponent.CurrentPosition().Load("x")
+		y, _ := opponent.CurrentPosition().Load("y")
+		rotation, _ := opponent.CurrentPosition().Load("rotation")
+		rotationRad := float64(rotation.(int)) * math.Pi / 180
+		for ticks := cutOffLookAhead / 3; ticks <= cutOffLookAhead; ticks += cutOffLookAhead / 3 {
+			targetX := float64(x.(int)) + math.Cos(rotationRad)*float64(ticks*pixelsPerMove)
+			targetY := float64(y.(int)) + math.Sin(rotationRad)*float64(ticks*pixelsPerMove)
+			if targetX < 0 || targetX >= float64(width) || targetY < 0 || targetY >= float64(height) {
+				break
+			}
+			distance := math.Hypot(targetX-float64(x0), targetY-float64(y0))
+			if distance/pixelsPerMove > float64(ticks) {
+				// the opponent would get there first
+				continue
+			}
+			angle := angleTo(x0, y0, targetX, targetY)
+			free := b.game.board.distanceToWall(x0, y0, angle)
+			if float64(free) >= distance && free >= cutOffSafeDistance {
+				return angle, []int{int(targetX), int(targetY)}
+			}
+		}
+	}
+	return findAngleToFarthestIntersection(distances), nil
+}
+
+// findAngleToOpenSpace returns the angle to the widest open area,
+// preferring the angles close to the current rotation, together
+// with the score of each direction
+func findAngleToOpenSpace(distances []int, rotation int) (int, []int) {
+	scores := make([]int, len(distances))
+	bestAngle, bestScore := rotation, math.MinInt32
+	for i := range distances {
+		angle := i * 10
+		scores[i] = distances[(i+35)%36] + 2*distances[i] + distances[(i+1)%36] -
+			cautiousTurnPenalty*angleDiff(rotation, angle)
+		if scores[i] > bestScore {
+			bestAngle, bestScore = angle, scores[i]
+		}
+	}
+	return bestAngle, scores
+}
+
+// delayDecision returns the angle the bot decided to head to
+// the given number of ticks ago
+func (b *Bot) delayDecision(angle, delay int) int {
+	b.decisions = append(b.decisions, angle)
+	if len(b.decisions) > delay+1 {
+		b.decisions = b.decisions[len(b.decisions)-delay-1:]
+	}
+	return b.decisions[0]
+}
+
+// closestOpponent returns the closest opponent which is still alive
+func (b *Bot) closestOpponent(x0, y0 int) Player {
+	var closest Player
+	closestDistance := math.MaxFloat64
+	for _, p := range b.game.lineup {
+		if p.ID() == b.id || !p.IsAlive() {
+			continue
+		}
+		x, _ := p.CurrentPosition().Load("x")
+		y, _ := p.CurrentPosition().Load("y")
+		distance := math.Hypot(float64(x.(int)-x0), float64(y.(int)-y0))
+		if distance < closestDistance {
+			closest, closestDistance = p, distance
+		}
+	}
+	return closest
+}
+
+// angleTo returns the angle in degrees from the first to the second point
+func angleTo(x0, y0 int, x1, y1 float64) int {
+	angle := int(math.Round(math.Atan2(y1-float64(y0), x1-float64(x0)) * 180 / math.Pi))
+	return (angle + 360) % 360
+}
+
+// angleDiff returns the smallest difference between two angles in degrees
+func angleDiff(a, b int) int {
+	diff := (a - b + 360) % 360
+	if diff > 180 {
+		return 360 - diff
+	}
+	return diff
+}
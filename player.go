@@ -1,14 +1,23 @@
 package main
 
 import (
-	"math"
 	"sync"
 	"time"
+
+	"github.com/lazareviczoran/blaster-twister/protocol"
 )
 
 const (
 	fps = 20
 
+	// Degrees a player turns on each tick of its rotation ticker
+	rotationStep = 5
+	// Interval of the rotation ticker while a direction is held
+	rotationInterval = 30 * time.Millisecond
+
+	// Maximum number of players in a single game
+	maxPlayers = 4
+
 	directionLeft  = "left"
 	directionRight = "right"
 	directionUp    = "up"
@@ -22,13 +31,12 @@ type Player interface {
 	StartRotation(direction string)
 	StopRotation()
 	ID() int
-	ClientID() int
 	Game() *Game
 	CurrentPosition() *sync.Map
 	IsAlive() bool
 	SetAlive(alive bool)
-	BroadcastCurrentPosition()
-	Broadcast(message []byte)
+	State() protocol.PlayerState
+	Broadcast(msg protocol.Message)
 	Destroy()
 }
 
@@ -36,14 +44,15 @@ type Player interface {
 type RotationData struct {
 	dir string
 	key string
+	// sequence number of the input, dropped inputs have no direction
+	seq int
 }
 
 // PlayerData contains the info about player and the players position
 type PlayerData struct {
 	id              int
-	clientID        int
 	game            *Game
-	send            chan []byte
+	send            chan protocol.Message
 	currentPosition *sync.Map
 	rotationChannel chan RotationData
 	stopRotation    chan bool
@@ -51,52 +60,42 @@ type PlayerData struct {
 	alive           bool
 }
 
-func moveBresenham(p Player, x0 int, y0 int, rotationRad float64) {
-	x1 := int(float64(x0) + math.Cos(rotationRad)*1000)
-	y1 := int(float64(y0) + math.Sin(rotationRad)*1000)
-
-	dx := int(math.Abs(float64(x1 - x0)))
-	dy := -(int(math.Abs(float64(y1 - y0))))
-	sx := 1
-	if x1 < x0 {
-		sx = -1
+// positionState converts the current position of a player
+// to its protocol representation
+func positionState(position *sync.Map) protocol.PlayerState {
+	x, _ := position.Load("x")
+	y, _ := position.Load("y")
+	rotation, _ := position.Load("rotation")
+	trace, _ := position.Load("trace")
+	state := protocol.PlayerState{X: x.(int), Y: y.(int), Rotation: rotation.(int), Trace: trace.(bool)}
+	if rotationDir, ok := position.Load("rotationDir"); ok && rotationDir != nil {
+		state.RotationDir = rotationDir.(string)
 	}
-	sy := 1
-	if y1 < y0 {
-		sy = -1
+	return state
+}
+
+// startPosition returns the field on which the player with the given id
+// starts the game, the players are spread evenly over the middle line
+func startPosition(id, players int) (int, int) {
+	if players < 2 {
+		return width / 2, height / 5
 	}
-	err := dx + dy
-	for i := 0; i < 3; i++ {
-		fromX := x0
-		fromY := y0
-		e2 := 2 * err
-		if e2 >= dy {
-			if x0 != x1 {
-				err += dy
-				x0 += sx
-			}
-		}
-		if e2 <= dx {
-			if y0 != y1 {
-				err += dx
-				y0 += sy
-			}
-		}
-		if p.Game().board.isValidMove(fromX, fromY, x0, y0) {
-			p.CurrentPosition().Store("x", x0)
-			p.CurrentPosition().Store("y", y0)
-			trace, _ := p.CurrentPosition().Load("trace")
-			if trace.(bool) {
-				p.Game().board.fields[x0][y0].setUsed(p)
-			}
-			if p.IsAlive() && p.Game().winner == nil {
-				p.BroadcastCurrentPosition()
-			}
-		} else {
-			if p.Game().endGame != nil {
-				p.Game().endGame <- p
-			}
-			return
+	return width / 2, height/5 + id*3*height/(5*(players-1))
+}
+
+// moveBresenham moves the player of a running game, its positions go into
+// the history and a crash is passed to the game loop
+func moveBresenham(p Player, x0 int, y0 int, rotationRad float64) {
+	game := p.Game()
+	trace, _ := p.CurrentPosition().Load("trace")
+	moved := game.board.move(p, x0, y0, rotationRad, trace.(bool), func(x, y int) {
+		p.CurrentPosition().Store("x", x)
+		p.CurrentPosition().Store("y", y)
+		if p.IsAlive() && game.winner == nil {
+			game.history.addPoint(p.ID(), x, y, trace.(bool))
 		}
+	})
+	if !moved && game.endGame != nil {
+		game.endGame <- p
 	}
 }
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/lazareviczoran/blaster-twister/protocol"
+)
+
+const (
+	minNicknameLength = 2
+	maxNicknameLength = 16
+)
+
+// Colors and rocket images the players can choose from
+var (
+	profileColors = []string{"green", "red", "yellow", "cyan", "magenta", "orange", "blue", "white"}
+	profileSkins  = []string{"rocket1", "rocket2"}
+)
+
+// Words which aren't allowed anywhere in a word of a nickname
+var profanity = []string{
+	"asshole", "bastard", "bitch", "bollock", "cunt", "fuck", "nazi",
+	"nigg", "penis", "pussy", "shit", "slut", "twat", "wank", "whore",
+}
+
+// Names and places which contain a word of the profanity list
+var profanityExceptions = []string{"nazia", "nazim", "nazir", "penistone", "scunthorpe"}
+
+// Words shorter than this are joined with their neighbours,
+// they may be a word which is spelled out
+const spelledOutLength = 3
+
+// Characters used to disguise letters
+var leetReplacer = strings.NewReplacer("0", "o", "1", "i", "3", "e", "4", "a", "5", "s", "7", "t", "@", "a", "$", "s", "!", "i")
+
+// validateProfile checks the chosen profile and returns it with the
+// nickname trimmed, empty fields keep the defaults of the client
+func validateProfile(p protocol.PlayerProfile) (protocol.PlayerProfile, error) {
+	p.Nickname = strings.Join(strings.Fields(p.Nickname), " ")
+	if p.Nickname != "" {
+		length := len([]rune(p.Nickname))
+		if length < minNicknameLength || length > maxNicknameLength {
+			return p, fmt.Errorf("The nickname must have between %d and %d characters", minNicknameLength, maxNicknameLength)
+		}
+		for _, r := range p.Nickname {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune(" _-.", r) {
+				return p, errors.New("The nickname can only contain letters, digits, spaces and _-.")
+			}
+		}
+		if isProfane(p.Nickname) {
+			return p, errors.New("The nickname is not allowed")
+		}
+	}
+	if p.Color != "" && !contains(profileColors, p.Color) {
+		return p, fmt.Errorf("Unknown color %q", p.Color)
+	}
+	if p.Skin != "" && !contains(profileSkins, p.Skin) {
+		return p, fmt.Errorf("Unknown skin %q", p.Skin)
+	}
+	return p, nil
+}
+
+// isProfane tells whether a word of the nickname contains a word of the
+// profanity list, also when it is disguised with digits or symbols or
+// spelled out with separators between the letters
+func isProfane(nickname string) bool {
+	words := strings.FieldsFunc(leetReplacer.Replace(strings.ToLower(nickname)), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	var spelled string
+	for _, word := range words {
+		if len([]rune(word)) < spelledOutLength {
+			spelled += word
+			continue
+		}
+		if containsProfanity(spelled) || containsProfanity(word) {
+			return true
+		}
+		spelled = ""
+	}
+	return containsProfanity(spelled)
+}
+
+// containsProfanity tells whether the word contains a word of the
+// profanity list outside of the exceptions
+func containsProfanity(word string) bool {
+	for _, exception := range profanityExceptions {
+		word = strings.Replace(word, exception, " ", -1)
+	}
+	for _, w := range profanity {
+		if strings.Contains(word, w) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// profileStore keeps the profiles chosen by the sessions, which are
+// used in the lobby, private rooms and games
+type profileStore struct {
+	mu       sync.Mutex
+	sessions map[string]protocol.PlayerProfile
+}
+
+var profiles = &profileStore{sessions: make(map[string]protocol.PlayerProfile)}
+
+func (s *profileStore) get(session string) protocol.PlayerProfile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sessions[session]
+}
+
+// set validates the profile and stores it for the session
+func (s *profileStore) set(session string, p protocol.PlayerProfile) (protocol.PlayerProfile, error) {
+	p, err := validateProfile(p)
+	if err != nil {
+		return p, err
+	}
+	if session == "" {
+		return p, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[session] = p
+	return p, nil
+}
+
+// serveProfile returns the profile of the session on GET and changes it
+// on POST, either with a JSON body or with the fields of a form
+func serveProfile(w http.ResponseWriter, r *http.Request) {
+	session := sessionID(w, r)
+	isJSON := strings.Contains(r.Header.Get("Content-Type"), "application/json")
+	switch r.Method {
+	case "GET":
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(profiles.get(session))
+	case "POST":
+		var p protocol.PlayerProfile
+		if isJSON {
+			if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+				http.Error(w, "Invalid profile", http.StatusBadRequest)
+				return
+			}
+		} else {
+			p = protocol.PlayerProfile{Nickname: r.FormValue("nickname"), Color: r.FormValue("color"), Skin: r.FormValue("skin")}
+		}
+		p, err := profiles.set(session, p)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !isJSON {
+			http.Redirect(w, r, "/", http.StatusSeeOther)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(p)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
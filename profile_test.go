@@ -0,0 +1,34 @@
+package main
+
+import "testing"
+
+func TestIsProfane(t *testing.T) {
+	tests := []struct {
+		nickname string
+		want     bool
+	}{
+		{"Player", false},
+		{"Dash It", false},
+		{"Art Watt", false},
+		{"Nazia", false},
+		{"Nazir", false},
+		{"Nazir_1", false},
+		{"Scunthorpe", false},
+		{"Penistone FC", false},
+		{"Sh It", true},
+		{"shit", true},
+		{"BullShit", true},
+		{"sh1t", true},
+		{"f u c k", true},
+		{"f.u.c.k you", true},
+		{"Mr Wanker", true},
+		{"nazi", true},
+		{"Nazir nazi", true},
+		{"Scunthorpe Twat", true},
+	}
+	for _, test := range tests {
+		if got := isProfane(test.nickname); got != test.want {
+			t.Errorf("isProfane(%q) = %v, want %v", test.nickname, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,334 @@
+package protocol
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Websocket subprotocols, clients which don't request one get JSON
+const (
+	JSONSubprotocol   = "blaster-twister.json"
+	BinarySubprotocol = "blaster-twister.binary"
+)
+
+// Subprotocols lists the supported subprotocols in the order of preference
+var Subprotocols = []string{BinarySubprotocol, JSONSubprotocol}
+
+// BinaryVersion is the protocol version of the binary frames, clients of
+// older versions get JSON, as their frames looked different. It is raised
+// with Version whenever a frame changes.
+const BinaryVersion = 5
+
+// Kinds of binary messages, stored in the first byte of each frame
+const (
+	binaryState     byte = 1
+	binaryCountdown byte = 2
+	binaryResult    byte = 3
+	binarySnapshot  byte = 4
+)
+
+// Flags of a player in a binary state message
+const (
+	flagTrace       byte = 1 << 0
+	flagRotateLeft  byte = 1 << 1
+	flagRotateRight byte = 1 << 2
+	flagBot         byte = 1 << 3
+	// bits 4-6 contain the index in personalityCodes
+	personalityShift = 4
+	personalityMask  = 7 << personalityShift
+	flagAlive        = 1 << 7
+
+	// set in the x coordinate of the snapshot points with a trace
+	pointTrace = 1 << 15
+)
+
+// Sizes of the parts of a binary snapshot
+const (
+	binarySnapshotHeaderSize = 10
+	binarySnapshotPlayerSize = binaryPlayerSize + 2
+	binaryPointSize          = 6
+	binaryPlayerExtraSize    = 7
+)
+
+// Size of a single player in a binary state message:
+// id, x, y, heading and flags
+const binaryPlayerSize = 7
+
+// personalityCodes maps the bot personalities to the codes in the flags
+var personalityCodes = []string{"", "standard", "aggressive", "cautious", "erratic"}
+
+// EncodeBinary encodes the message in the compact binary format.
+// It returns false for the messages which are only sent as JSON.
+//
+// A state frame holds any number of players:
+//
+//	byte 0:    1 (state)
+//	byte 1:    number of players
+//	per player: id (1 byte), x (2 bytes), y (2 bytes),
+//	            heading (1 byte, 256 steps per turn), flags (1 byte)
+//
+// A snapshot frame contains the players together with their points:
+//
+//	byte 0:     4 (snapshot)
+//	bytes 1-8:  tick (4 bytes), base (4 bytes)
+//	byte 9:     number of players
+//	per player: the same 7 bytes as in the state frame, with the
+//	            highest flag marking alive players, number of points (2 bytes)
+//	per point:  ticks after the base (2 bytes), x (2 bytes, the highest
+//	            bit marks a trace), y (2 bytes)
+//	optionally: number of players with a last input or a ping (1 byte),
+//	            followed by their id (1 byte), last input sequence
+//	            (4 bytes) and ping in milliseconds (2 bytes)
+//
+// A countdown frame is 2 (countdown) followed by the seconds left and
+// optionally the start time in milliseconds (8 bytes). A result frame is
+// 3 (result) followed by the id of the winner and the ids of the players
+// which forfeited.
+func EncodeBinary(msg Message) ([]byte, bool) {
+	switch msg := msg.(type) {
+	case Snapshot:
+		return encodeBinarySnapshot(&msg), true
+	case State:
+		return encodeBinaryState(&msg), true
+	case *State:
+		return encodeBinaryState(msg), true
+	case Countdown:
+		data := []byte{binaryCountdown, byte(msg.Countdown)}
+		if msg.StartAt != 0 {
+			var startAt [8]byte
+			binary.BigEndian.PutUint64(startAt[:], uint64(msg.StartAt))
+			data = append(data, startAt[:]...)
+		}
+		return data, true
+	case Result:
+		data := []byte{binaryResult, byte(msg.Winner)}
+		for _, id := range msg.Forfeited {
+			data = append(data, byte(id))
+		}
+		return data, true
+	}
+	return nil, false
+}
+
+func encodeBinaryState(msg *State) []byte {
+	ids := make([]int, 0, len(msg.Players))
+	for id := range msg.Players {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	data := make([]byte, 2, 2+len(ids)*binaryPlayerSize)
+	data[0] = binaryState
+	data[1] = byte(len(ids))
+	for _, id := range ids {
+		p := msg.Players[id]
+		var player [binaryPlayerSize]byte
+		putBinaryPlayer(player[:], id, &p)
+		data = append(data, player[:]...)
+	}
+	return data
+}
+
+func putBinaryPlayer(data []byte, id int, p *PlayerState) {
+	data[0] = byte(id)
+	binary.BigEndian.PutUint16(data[1:3], uint16(p.X))
+	binary.BigEndian.PutUint16(data[3:5], uint16(p.Y))
+	data[5] = byte(((p.Rotation%360+360)%360*256 + 180) / 360 % 256)
+	data[6] = playerFlags(p)
+}
+
+func encodeBinarySnapshot(msg *Snapshot) []byte {
+	ids := make([]int, 0, len(msg.Players))
+	size := binarySnapshotHeaderSize
+	extras := 0
+	for id, p := range msg.Players {
+		ids = append(ids, id)
+		size += binarySnapshotPlayerSize + len(p.Points)*binaryPointSize
+		if p.LastInput > 0 || p.Ping > 0 {
+			extras++
+		}
+	}
+	sort.Ints(ids)
+	if extras > 0 {
+		size += 1 + extras*binaryPlayerExtraSize
+	}
+
+	data := make([]byte, size)
+	data[0] = binarySnapshot
+	binary.BigEndian.PutUint32(data[1:5], uint32(msg.Tick))
+	binary.BigEndian.PutUint32(data[5:9], uint32(msg.Base))
+	data[9] = byte(len(ids))
+	offset := binarySnapshotHeaderSize
+	for _, id := range ids {
+		p := msg.Players[id]
+		putBinaryPlayer(data[offset:], id, &p.PlayerState)
+		if p.Alive {
+			data[offset+6] |= flagAlive
+		}
+		binary.BigEndian.PutUint16(data[offset+7:offset+9], uint16(len(p.Points)))
+		offset += binarySnapshotPlayerSize
+		for _, point := range p.Points {
+			x := uint16(point[1])
+			if point[3] != 0 {
+				x |= pointTrace
+			}
+			binary.BigEndian.PutUint16(data[offset:offset+2], uint16(point[0]-msg.Base))
+			binary.BigEndian.PutUint16(data[offset+2:offset+4], x)
+			binary.BigEndian.PutUint16(data[offset+4:offset+6], uint16(point[2]))
+			offset += binaryPointSize
+		}
+	}
+	if extras > 0 {
+		data[offset] = byte(extras)
+		offset++
+		for _, id := range ids {
+			if p := msg.Players[id]; p.LastInput > 0 || p.Ping > 0 {
+				data[offset] = byte(id)
+				binary.BigEndian.PutUint32(data[offset+1:offset+5], uint32(p.LastInput))
+				binary.BigEndian.PutUint16(data[offset+5:offset+7], uint16(p.Ping))
+				offset += binaryPlayerExtraSize
+			}
+		}
+	}
+	return data
+}
+
+func playerFlags(p *PlayerState) byte {
+	var flags byte
+	if p.Trace {
+		flags |= flagTrace
+	}
+	switch p.RotationDir {
+	case "left":
+		flags |= flagRotateLeft
+	case "right":
+		flags |= flagRotateRight
+	}
+	if p.Bot {
+		flags |= flagBot
+	}
+	for code, personality := range personalityCodes {
+		if code > 0 && personality == p.Personality {
+			flags |= byte(code) << personalityShift
+		}
+	}
+	return flags
+}
+
+// DecodeBinary parses a message encoded with EncodeBinary
+func DecodeBinary(data []byte) (Message, error) {
+	if len(data) == 0 {
+		return nil, errors.New("empty binary message")
+	}
+	switch data[0] {
+	case binaryState:
+		if len(data) < 2 || len(data) != 2+int(data[1])*binaryPlayerSize {
+			return nil, fmt.Errorf("invalid binary state of %d bytes", len(data))
+		}
+		msg := &State{Players: make(map[int]PlayerState, data[1])}
+		for i := 2; i < len(data); i += binaryPlayerSize {
+			id, p := readBinaryPlayer(data[i : i+binaryPlayerSize])
+			msg.Players[id] = p
+		}
+		return msg, nil
+	case binarySnapshot:
+		return decodeBinarySnapshot(data)
+	case binaryCountdown:
+		if len(data) != 2 && len(data) != 10 {
+			return nil, fmt.Errorf("invalid binary message of %d bytes", len(data))
+		}
+		msg := &Countdown{Countdown: int(data[1])}
+		if len(data) == 10 {
+			msg.StartAt = int64(binary.BigEndian.Uint64(data[2:10]))
+		}
+		return msg, nil
+	case binaryResult:
+		if len(data) < 2 {
+			return nil, fmt.Errorf("invalid binary message of %d bytes", len(data))
+		}
+		msg := &Result{Winner: int(data[1])}
+		for _, id := range data[2:] {
+			msg.Forfeited = append(msg.Forfeited, int(id))
+		}
+		return msg, nil
+	}
+	return nil, fmt.Errorf("unknown binary message kind %d", data[0])
+}
+
+func readBinaryPlayer(data []byte) (int, PlayerState) {
+	flags := data[6]
+	p := PlayerState{
+		X:        int(binary.BigEndian.Uint16(data[1:3])),
+		Y:        int(binary.BigEndian.Uint16(data[3:5])),
+		Rotation: (int(data[5])*360 + 128) / 256,
+		Trace:    flags&flagTrace != 0,
+		Bot:      flags&flagBot != 0,
+	}
+	if flags&flagRotateLeft != 0 {
+		p.RotationDir = "left"
+	} else if flags&flagRotateRight != 0 {
+		p.RotationDir = "right"
+	}
+	if code := int(flags&personalityMask) >> personalityShift; code < len(personalityCodes) {
+		p.Personality = personalityCodes[code]
+	}
+	return int(data[0]), p
+}
+
+func decodeBinarySnapshot(data []byte) (Message, error) {
+	if len(data) < binarySnapshotHeaderSize {
+		return nil, fmt.Errorf("invalid binary snapshot of %d bytes", len(data))
+	}
+	msg := &Snapshot{
+		Tick:    int(binary.BigEndian.Uint32(data[1:5])),
+		Base:    int(binary.BigEndian.Uint32(data[5:9])),
+		Players: make(map[int]SnapshotPlayer, data[9]),
+	}
+	offset := binarySnapshotHeaderSize
+	for i := 0; i < int(data[9]); i++ {
+		if len(data) < offset+binarySnapshotPlayerSize {
+			return nil, fmt.Errorf("invalid binary snapshot of %d bytes", len(data))
+		}
+		id, state := readBinaryPlayer(data[offset : offset+binaryPlayerSize])
+		p := SnapshotPlayer{PlayerState: state, Alive: data[offset+6]&flagAlive != 0}
+		points := int(binary.BigEndian.Uint16(data[offset+7 : offset+9]))
+		offset += binarySnapshotPlayerSize
+		if len(data) < offset+points*binaryPointSize {
+			return nil, fmt.Errorf("invalid binary snapshot of %d bytes", len(data))
+		}
+		p.Points = make([][4]int, points)
+		for j := range p.Points {
+			x := binary.BigEndian.Uint16(data[offset+2 : offset+4])
+			trace := 0
+			if x&pointTrace != 0 {
+				trace = 1
+			}
+			p.Points[j] = [4]int{
+				msg.Base + int(binary.BigEndian.Uint16(data[offset:offset+2])),
+				int(x &^ pointTrace),
+				int(binary.BigEndian.Uint16(data[offset+4 : offset+6])),
+				trace,
+			}
+			offset += binaryPointSize
+		}
+		msg.Players[id] = p
+	}
+	if offset < len(data) {
+		extras := int(data[offset])
+		offset++
+		if len(data) != offset+extras*binaryPlayerExtraSize {
+			return nil, fmt.Errorf("invalid binary snapshot of %d bytes", len(data))
+		}
+		for ; offset < len(data); offset += binaryPlayerExtraSize {
+			id := int(data[offset])
+			if p, ok := msg.Players[id]; ok {
+				p.LastInput = int(binary.BigEndian.Uint32(data[offset+1 : offset+5]))
+				p.Ping = int(binary.BigEndian.Uint16(data[offset+5 : offset+7]))
+				msg.Players[id] = p
+			}
+		}
+	}
+	return msg, nil
+}
@@ -0,0 +1,87 @@
+package protocol
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+type header struct {
+	Type string `json:"type"`
+}
+
+// Encode converts the message to JSON and adds its type
+func Encode(msg Message) ([]byte, error) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 2 || data[0] != '{' {
+		return nil, fmt.Errorf("message %s is not a JSON object", msg.MessageType())
+	}
+	var buf bytes.Buffer
+	buf.Grow(len(data) + len(msg.MessageType()) + 11)
+	buf.WriteString(`{"type":"`)
+	buf.WriteString(msg.MessageType())
+	buf.WriteByte('"')
+	if len(data) > 2 {
+		buf.WriteByte(',')
+	}
+	buf.Write(data[1:])
+	return buf.Bytes(), nil
+}
+
+// Decode parses a message, the returned value is a pointer to one of the
+// message structs. Messages without a type are parsed as legacy messages.
+func Decode(data []byte) (Message, error) {
+	var h header
+	if err := json.Unmarshal(data, &h); err != nil {
+		return nil, err
+	}
+	if h.Type == "" {
+		return decodeLegacy(data)
+	}
+	newMessage, ok := messages[h.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown message type %q", h.Type)
+	}
+	msg := newMessage()
+	if err := json.Unmarshal(data, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// decodeLegacy parses the messages of the legacy clients,
+// which are told apart by their fields
+func decodeLegacy(data []byte) (Message, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	var msg Message
+	switch {
+	case fields["dir"] != nil:
+		msg = &Input{}
+	case fields["clientId"] != nil:
+		msg = &Hello{}
+	default:
+		return nil, fmt.Errorf("message without a type: %s", data)
+	}
+	if err := json.Unmarshal(data, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// Negotiate returns the version used with a client which supports
+// the given version
+func Negotiate(clientVersion int) (int, error) {
+	if clientVersion < MinVersion {
+		return 0, fmt.Errorf("protocol version %d is not supported, the oldest supported version is %d", clientVersion, MinVersion)
+	}
+	if clientVersion > Version {
+		return Version, nil
+	}
+	return clientVersion, nil
+}
@@ -0,0 +1,24 @@
+package protocol
+
+import "testing"
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		client  int
+		version int
+		ok      bool
+	}{
+		{-1, 0, false},
+		{0, 0, true},
+		{1, 1, true},
+		{2, 2, true},
+		{Version, Version, true},
+		{Version + 1, Version, true},
+	}
+	for _, test := range tests {
+		version, err := Negotiate(test.client)
+		if (err == nil) != test.ok || (test.ok && version != test.version) {
+			t.Errorf("Negotiate(%d) = %d, %v, want version %d", test.client, version, err, test.version)
+		}
+	}
+}
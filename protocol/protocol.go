@@ -0,0 +1,522 @@
+// Package protocol contains the messages exchanged between the game server
+// and its clients, together with the codec used to encode and decode them.
+//
+// Every message is a JSON object with a "type" field, the rest of the fields
+// depend on the type. The JSON Schema of all messages is in schema.json.
+package protocol
+
+//go:generate go run ./schemagen -o schema.json
+
+// Version is the newest protocol version supported by the server, it is
+// raised with every change older clients can't follow.
+//
+// Version 1 sends a state message for every step of every player,
+// version 2 sends a single snapshot per tick, version 3 acknowledges
+// the numbered inputs in the snapshots, version 4 identifies the seats
+// by signed tokens instead of ids chosen by the clients and version 5
+// measures the latency with pings and sends the start time with the
+// countdown.
+const Version = 5
+
+// MinVersion is the oldest protocol version supported by the server,
+// clients which don't send a version use the legacy version 0
+const MinVersion = 0
+
+// Message types
+const (
+	TypeJoin         = "join"
+	TypeAssigned     = "assigned"
+	TypeInput        = "input"
+	TypeHello        = "hello"
+	TypeState        = "state"
+	TypeSnapshot     = "snapshot"
+	TypeAck          = "ack"
+	TypePing         = "ping"
+	TypePong         = "pong"
+	TypePlayer       = "player"
+	TypeCountdown    = "countdown"
+	TypeResult       = "result"
+	TypeDebug        = "debug"
+	TypeRoom         = "room"
+	TypeReady        = "ready"
+	TypeSettings     = "settings"
+	TypeStart        = "start"
+	TypeQueued       = "queued"
+	TypeMatch        = "match"
+	TypeCancel       = "cancel"
+	TypeDequeued     = "dequeued"
+	TypeReadyCheck   = "readyCheck"
+	TypeRematchOffer = "rematchOffer"
+	TypeRematch      = "rematch"
+	TypeProfile      = "profile"
+	TypePlayers      = "players"
+	TypeParty        = "party"
+	TypeQueue        = "queue"
+	TypeError        = "error"
+)
+
+// Message is implemented by all messages of the protocol
+type Message interface {
+	MessageType() string
+}
+
+// Join is sent by the client right after connecting to the game socket
+type Join struct {
+	// Newest protocol version supported by the client
+	Version int `json:"version"`
+	// Seat token from the assigned message, used to take the seat
+	// back after the connection was lost
+	Resume string `json:"resume,omitempty"`
+	// Token of a seat reserved for the client, e.g. by a private room or a rematch
+	Seat string `json:"seat,omitempty"`
+	// How the player is shown to the others, the profile
+	// of the players session is used without it
+	Profile *PlayerProfile `json:"profile,omitempty"`
+}
+
+// Assigned tells the client which player it controls
+type Assigned struct {
+	PlayerID int `json:"playerId"`
+	// Protocol version used for the rest of the connection
+	Version int `json:"version"`
+	// Signed token of the seat, used for resuming the game after a
+	// dropped connection and for the command connection of legacy clients
+	Token string `json:"token,omitempty"`
+}
+
+// Input is a key press or release of the player
+type Input struct {
+	// "down" or "up"
+	Dir string `json:"dir"`
+	// "left" or "right"
+	Key string `json:"key"`
+	// Increasing number of the input, starting at 1
+	Seq int `json:"seq,omitempty"`
+	// Client time of the input in milliseconds since the epoch
+	Time int64 `json:"time,omitempty"`
+}
+
+// Hello was sent by the legacy clients with an id they chose themselves.
+// It is ignored, the seat token identifies the player instead.
+type Hello struct {
+	ClientID string `json:"clientId"`
+}
+
+// PlayerState is the current position of a player
+type PlayerState struct {
+	X        int  `json:"x"`
+	Y        int  `json:"y"`
+	Rotation int  `json:"rotation"`
+	Trace    bool `json:"trace"`
+	// "left" or "right" while the player is turning
+	RotationDir string `json:"rotationDir,omitempty"`
+	Bot         bool   `json:"bot,omitempty"`
+	Personality string `json:"personality,omitempty"`
+	// Sequence number of the last input the server processed
+	LastInput int `json:"lastInput,omitempty"`
+	// Round trip time of the player in milliseconds
+	Ping int `json:"ping,omitempty"`
+}
+
+// State contains the positions of the players by their ids
+type State struct {
+	Players map[int]PlayerState `json:"players"`
+}
+
+// SnapshotPlayer is the state of a player at the tick of a snapshot,
+// together with its positions since the base tick
+type SnapshotPlayer struct {
+	PlayerState
+	Alive bool `json:"alive"`
+	// Positions after the base tick, each as [tick, x, y, trace]
+	Points [][4]int `json:"points"`
+}
+
+// Snapshot is the state of all players at the end of a tick. It only
+// contains the positions after the base tick, which is the last tick
+// acknowledged by the client, a base of 0 contains the whole game.
+type Snapshot struct {
+	Tick    int                    `json:"tick"`
+	Base    int                    `json:"base"`
+	Players map[int]SnapshotPlayer `json:"players"`
+}
+
+// Ack acknowledges that the client has applied the snapshot of the tick
+type Ack struct {
+	Tick int `json:"tick"`
+}
+
+// Player events
+const (
+	EventDisconnected = "disconnected"
+	EventReconnected  = "reconnected"
+	EventEliminated   = "eliminated"
+)
+
+// Causes of eliminating a player
+const (
+	CauseCrashed      = "crashed"
+	CauseDisconnected = "disconnected"
+)
+
+// PlayerEvent tells the players about a change of one of them
+type PlayerEvent struct {
+	PlayerID int `json:"playerId"`
+	// "disconnected", "reconnected" or "eliminated"
+	Event string `json:"event"`
+	// "crashed" or "disconnected" for eliminated players
+	Cause string `json:"cause,omitempty"`
+}
+
+// Ping is sent by the server every few seconds to measure the latency,
+// the client answers right away with a pong
+type Ping struct {
+	// Server time in milliseconds since the epoch
+	Time int64 `json:"time"`
+	// Round trip time measured so far in milliseconds
+	RTT int `json:"rtt"`
+	// Difference of the client clock to the server clock in milliseconds,
+	// a server time plus the offset is the client time
+	Offset int64 `json:"offset"`
+}
+
+// Pong answers a ping
+type Pong struct {
+	// Time of the ping
+	Time int64 `json:"time"`
+	// Client time when answering in milliseconds since the epoch
+	ClientTime int64 `json:"clientTime"`
+}
+
+// Countdown is sent every second before the game starts
+type Countdown struct {
+	Countdown int `json:"countdown"`
+	// Server time in milliseconds since the epoch at which the game starts
+	StartAt int64 `json:"startAt,omitempty"`
+}
+
+// Result is sent when the game is over
+type Result struct {
+	Winner int `json:"winner"`
+	// Players which didn't reconnect in time
+	Forfeited []int `json:"forfeited,omitempty"`
+}
+
+// BotDecision contains the internals of a bots decision in a single tick
+type BotDecision struct {
+	Bot         int    `json:"bot"`
+	Personality string `json:"personality"`
+	X           int    `json:"x"`
+	Y           int    `json:"y"`
+	Rotation    int    `json:"rotation"`
+	// Distances to the closest wall in 10 degree steps
+	Rays []int `json:"rays"`
+	// Scores of the directions in 10 degree steps, the highest one is chosen
+	Scores []int `json:"scores"`
+	// Angle chosen by the bots strategy
+	Chosen int `json:"chosen"`
+	// Angle the bot is steering to after mistakes and reaction delay
+	Target  int  `json:"target"`
+	Mistake bool `json:"mistake"`
+	// Point in front of the opponent the aggressive bot tries to reach
+	CutOff []int `json:"cutOff,omitempty"`
+}
+
+// Debug streams a bot decision to the debug overlay
+type Debug struct {
+	Debug BotDecision `json:"debug"`
+}
+
+// PlayerProfile is how a player is shown to the others, players without
+// a nickname are shown by their number
+type PlayerProfile struct {
+	Nickname string `json:"nickname,omitempty"`
+	// One of the colors offered by the server
+	Color string `json:"color,omitempty"`
+	// Image of the rocket, "rocket1" or "rocket2"
+	Skin string `json:"skin,omitempty"`
+}
+
+// Profile changes the profile of the players session, it is
+// accepted on the lobby and room sockets
+type Profile struct {
+	PlayerProfile
+}
+
+// PlayerInfo describes a player of the game
+type PlayerInfo struct {
+	ID int `json:"id"`
+	PlayerProfile
+	Bot         bool   `json:"bot,omitempty"`
+	Personality string `json:"personality,omitempty"`
+}
+
+// Players is sent whenever a player joins the game or resumes
+// its seat, with the profiles of all players
+type Players struct {
+	Players []PlayerInfo `json:"players"`
+}
+
+// RoomMember is a player waiting in a private room
+type RoomMember struct {
+	ID int `json:"id"`
+	PlayerProfile
+	Host  bool `json:"host"`
+	Ready bool `json:"ready"`
+}
+
+// RoomSettings are chosen by the host of a private room
+type RoomSettings struct {
+	Bots int `json:"bots"`
+	// Personalities of the bots, random ones are picked for the rest
+	Personalities []string `json:"personalities,omitempty"`
+}
+
+// Room is the state of a private room, sent to all members on every change
+type Room struct {
+	Code string `json:"code"`
+	Link string `json:"link"`
+	// Id of the member receiving the message
+	You      int          `json:"you"`
+	Members  []RoomMember `json:"members"`
+	Settings RoomSettings `json:"settings"`
+}
+
+// Settings is sent by the host to change the settings of the room
+type Settings struct {
+	RoomSettings
+}
+
+// Ready tells whether a member of a private room is ready to play,
+// in the lobby it confirms a ready check
+type Ready struct {
+	Ready bool `json:"ready"`
+}
+
+// Start is sent by the host of a private room to start the game,
+// the server answers all members with the id of the game
+type Start struct {
+	Game string `json:"game,omitempty"`
+	// Token of the seat reserved for the player, which it sends in its join message
+	Seat string `json:"seat,omitempty"`
+}
+
+// RematchOffer is sent at the end of a game, the players can accept the rematch
+// at /ws/rematch/{gameID} with their seat token. The accepting players get the
+// offer again with the players who accepted, and a start message with the new
+// game once everyone accepted.
+type RematchOffer struct {
+	// Time to accept in seconds
+	Timeout int `json:"timeout"`
+	// Wins of each player in the series of rematches, by the player ids
+	Wins []int `json:"wins"`
+	// Number of games played in the series
+	Games    int   `json:"games"`
+	Accepted []int `json:"accepted,omitempty"`
+}
+
+// Rematch accepts or declines a rematch offer
+type Rematch struct {
+	Accept bool `json:"accept"`
+}
+
+// Queued tells a player waiting in the lobby its place in the queue
+type Queued struct {
+	// Position in the queue, starting with 1
+	Position int `json:"position"`
+	// Estimated time until a game is found in seconds
+	EstimatedWait int `json:"estimatedWait"`
+}
+
+// Opponent describes an opponent of a match
+type Opponent struct {
+	PlayerProfile
+	Rating      int    `json:"rating,omitempty"`
+	Bot         bool   `json:"bot,omitempty"`
+	Personality string `json:"personality,omitempty"`
+	// Whether the opponent is a member of the players party
+	Party bool `json:"party,omitempty"`
+}
+
+// Match tells a player waiting in the lobby to join the game
+type Match struct {
+	Game string `json:"game"`
+	// Base URL of the server hosting the game, empty when
+	// the server of the lobby hosts all games
+	Host      string     `json:"host,omitempty"`
+	Opponents []Opponent `json:"opponents"`
+}
+
+// ReadyCheck asks a matched player in the lobby to confirm with a ready
+// message, players who don't confirm in time go back to the queue
+type ReadyCheck struct {
+	// Time to confirm in seconds
+	Timeout   int        `json:"timeout"`
+	Opponents []Opponent `json:"opponents"`
+}
+
+// Cancel is sent by a player who stops waiting in the lobby,
+// in a party only the leader can send it
+type Cancel struct{}
+
+// Reasons of leaving the lobby queue
+const (
+	ReasonCancelled = "cancelled"
+	ReasonTimeout   = "timeout"
+	// A member left the party
+	ReasonPartyChanged = "partyChanged"
+)
+
+// PartyMember is a player of a party in the lobby
+type PartyMember struct {
+	ID int `json:"id"`
+	PlayerProfile
+	Rating int  `json:"rating"`
+	Leader bool `json:"leader"`
+}
+
+// Party is the state of a party in the lobby, sent to all members on
+// every change. The party is queued and matched as a single entry, so
+// all members end up in the same game.
+type Party struct {
+	Code string `json:"code"`
+	Link string `json:"link"`
+	// Id of the member receiving the message
+	You     int           `json:"you"`
+	Members []PartyMember `json:"members"`
+	// Whether the party is waiting for a game
+	Queued bool `json:"queued"`
+}
+
+// Queue is sent by the leader of a party to start waiting for a game
+type Queue struct{}
+
+// Dequeued tells a player that it left the lobby queue without a game
+type Dequeued struct {
+	Reason string `json:"reason"`
+}
+
+// Error tells the client why its message couldn't be handled
+type Error struct {
+	Error string `json:"error"`
+}
+
+// MessageType implements Message
+func (Join) MessageType() string { return TypeJoin }
+
+// MessageType implements Message
+func (Assigned) MessageType() string { return TypeAssigned }
+
+// MessageType implements Message
+func (Input) MessageType() string { return TypeInput }
+
+// MessageType implements Message
+func (Hello) MessageType() string { return TypeHello }
+
+// MessageType implements Message
+func (State) MessageType() string { return TypeState }
+
+// MessageType implements Message
+func (Snapshot) MessageType() string { return TypeSnapshot }
+
+// MessageType implements Message
+func (Ack) MessageType() string { return TypeAck }
+
+// MessageType implements Message
+func (Ping) MessageType() string { return TypePing }
+
+// MessageType implements Message
+func (Pong) MessageType() string { return TypePong }
+
+// MessageType implements Message
+func (PlayerEvent) MessageType() string { return TypePlayer }
+
+// MessageType implements Message
+func (Countdown) MessageType() string { return TypeCountdown }
+
+// MessageType implements Message
+func (Result) MessageType() string { return TypeResult }
+
+// MessageType implements Message
+func (Debug) MessageType() string { return TypeDebug }
+
+// MessageType implements Message
+func (Room) MessageType() string { return TypeRoom }
+
+// MessageType implements Message
+func (Ready) MessageType() string { return TypeReady }
+
+// MessageType implements Message
+func (Settings) MessageType() string { return TypeSettings }
+
+// MessageType implements Message
+func (Start) MessageType() string { return TypeStart }
+
+// MessageType implements Message
+func (Queued) MessageType() string { return TypeQueued }
+
+// MessageType implements Message
+func (Match) MessageType() string { return TypeMatch }
+
+// MessageType implements Message
+func (ReadyCheck) MessageType() string { return TypeReadyCheck }
+
+// MessageType implements Message
+func (RematchOffer) MessageType() string { return TypeRematchOffer }
+
+// MessageType implements Message
+func (Rematch) MessageType() string { return TypeRematch }
+
+// MessageType implements Message
+func (Profile) MessageType() string { return TypeProfile }
+
+// MessageType implements Message
+func (Players) MessageType() string { return TypePlayers }
+
+// MessageType implements Message
+func (Party) MessageType() string { return TypeParty }
+
+// MessageType implements Message
+func (Queue) MessageType() string { return TypeQueue }
+
+// MessageType implements Message
+func (Cancel) MessageType() string { return TypeCancel }
+
+// MessageType implements Message
+func (Dequeued) MessageType() string { return TypeDequeued }
+
+// MessageType implements Message
+func (Error) MessageType() string { return TypeError }
+
+// messages creates an empty message for each type
+var messages = map[string]func() Message{
+	TypeJoin:         func() Message { return &Join{} },
+	TypeAssigned:     func() Message { return &Assigned{} },
+	TypeInput:        func() Message { return &Input{} },
+	TypeHello:        func() Message { return &Hello{} },
+	TypeState:        func() Message { return &State{} },
+	TypeSnapshot:     func() Message { return &Snapshot{} },
+	TypeAck:          func() Message { return &Ack{} },
+	TypePing:         func() Message { return &Ping{} },
+	TypePong:         func() Message { return &Pong{} },
+	TypePlayer:       func() Message { return &PlayerEvent{} },
+	TypeCountdown:    func() Message { return &Countdown{} },
+	TypeResult:       func() Message { return &Result{} },
+	TypeDebug:        func() Message { return &Debug{} },
+	TypeRoom:         func() Message { return &Room{} },
+	TypeReady:        func() Message { return &Ready{} },
+	TypeSettings:     func() Message { return &Settings{} },
+	TypeStart:        func() Message { return &Start{} },
+	TypeQueued:       func() Message { return &Queued{} },
+	TypeMatch:        func() Message { return &Match{} },
+	TypeCancel:       func() Message { return &Cancel{} },
+	TypeDequeued:     func() Message { return &Dequeued{} },
+	TypeReadyCheck:   func() Message { return &ReadyCheck{} },
+	TypeRematchOffer: func() Message { return &RematchOffer{} },
+	TypeRematch:      func() Message { return &Rematch{} },
+	TypeProfile:      func() Message { return &Profile{} },
+	TypePlayers:      func() Message { return &Players{} },
+	TypeParty:        func() Message { return &Party{} },
+	TypeQueue:        func() Message { return &Queue{} },
+	TypeError:        func() Message { return &Error{} },
+}
@@ -0,0 +1,109 @@
+package protocol
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+type schemaBuilder struct {
+	definitions map[string]interface{}
+}
+
+// Schema returns the JSON Schema of all messages
+func Schema() ([]byte, error) {
+	b := &schemaBuilder{definitions: make(map[string]interface{})}
+	types := make([]string, 0, len(messages))
+	for t := range messages {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	oneOf := make([]interface{}, 0, len(types))
+	for _, t := range types {
+		msg := messages[t]()
+		msgType := reflect.TypeOf(msg).Elem()
+		definition := b.object(msgType)
+		definition["properties"].(map[string]interface{})["type"] = map[string]interface{}{"const": t}
+		definition["required"] = append([]string{"type"}, definition["required"].([]string)...)
+		b.definitions[msgType.Name()] = definition
+		oneOf = append(oneOf, ref(msgType))
+	}
+
+	schema := map[string]interface{}{
+		"$schema":     "http://json-schema.org/draft-07/schema#",
+		"title":       "blaster-twister protocol",
+		"version":     Version,
+		"oneOf":       oneOf,
+		"definitions": b.definitions,
+	}
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+func ref(t reflect.Type) map[string]interface{} {
+	return map[string]interface{}{"$ref": "#/definitions/" + t.Name()}
+}
+
+func (b *schemaBuilder) object(t reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{})
+	required := make([]string, 0)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			embedded := b.object(field.Type)
+			for name, property := range embedded["properties"].(map[string]interface{}) {
+				properties[name] = property
+			}
+			required = append(required, embedded["required"].([]string)...)
+			continue
+		}
+		tag := strings.Split(field.Tag.Get("json"), ",")
+		if tag[0] == "-" || field.PkgPath != "" {
+			continue
+		}
+		name := tag[0]
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = b.schema(field.Type)
+		if len(tag) == 1 || tag[1] != "omitempty" {
+			required = append(required, name)
+		}
+	}
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
+
+func (b *schemaBuilder) schema(t reflect.Type) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return b.schema(t.Elem())
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": b.schema(t.Elem())}
+	case reflect.Map:
+		// JSON object keys are always strings, integer keys are written as numbers
+		return map[string]interface{}{"type": "object", "additionalProperties": b.schema(t.Elem())}
+	case reflect.Struct:
+		if _, ok := b.definitions[t.Name()]; !ok {
+			b.definitions[t.Name()] = nil
+			b.definitions[t.Name()] = b.object(t)
+		}
+		return ref(t)
+	default:
+		return map[string]interface{}{}
+	}
+}
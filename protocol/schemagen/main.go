@@ -0,0 +1,29 @@
+// Command schemagen writes the JSON Schema of the protocol messages
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/lazareviczoran/blaster-twister/protocol"
+)
+
+func main() {
+	output := flag.String("o", "", "output file, defaults to stdout")
+	flag.Parse()
+
+	schema, err := protocol.Schema()
+	if err != nil {
+		log.Fatal("Schema: ", err)
+	}
+	schema = append(schema, '\n')
+	if *output == "" {
+		os.Stdout.Write(schema)
+		return
+	}
+	if err := ioutil.WriteFile(*output, schema, 0644); err != nil {
+		log.Fatal("WriteFile: ", err)
+	}
+}
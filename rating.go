@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"log"
+	"math"
+	"os"
+	"sync"
+	"time"
+)
+
+// Glicko parameters, see http://www.glicko.net/glicko/glicko.pdf
+const (
+	initialRating    = 1500.0
+	initialDeviation = 350.0
+	minDeviation     = 30.0
+	// growth of the deviation per day without games, an inactive
+	// player returns to the initial deviation after about 100 days
+	deviationGrowth = 34.6
+)
+
+var glickoQ = math.Ln10 / 400
+
+var ratingsFile = flag.String("ratings", "", "file in which the player ratings are kept across restarts, empty keeps them in memory")
+
+var ratings *ratingStore
+
+// Rating is the Glicko rating of a player
+type Rating struct {
+	Rating     float64   `json:"rating"`
+	Deviation  float64   `json:"deviation"`
+	Games      int       `json:"games"`
+	LastPlayed time.Time `json:"lastPlayed"`
+}
+
+// ratingStore keeps the ratings of the sessions which played
+// matchmade games, and saves them to its file after each update
+type ratingStore struct {
+	mu       sync.Mutex
+	path     string
+	sessions map[string]*Rating
+}
+
+func initRatings() {
+	ratings = &ratingStore{path: *ratingsFile, sessions: make(map[string]*Rating)}
+	if ratings.path == "" {
+		return
+	}
+	data, err := ioutil.ReadFile(ratings.path)
+	if os.IsNotExist(err) {
+		return
+	}
+	if err == nil {
+		err = json.Unmarshal(data, &ratings.sessions)
+	}
+	if err != nil {
+		log.Fatalf("Could not load the ratings from %s, %v", ratings.path, err)
+	}
+	log.Printf("Loaded %d ratings from %s", len(ratings.sessions), ratings.path)
+}
+
+// get returns the current rating of the session, its deviation
+// grows with the time since the last game
+func (s *ratingStore) get(session string) Rating {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.current(session, time.Now())
+}
+
+func (s *ratingStore) current(session string, now time.Time) Rating {
+	r, ok := s.sessions[session]
+	if !ok {
+		return Rating{Rating: initialRating, Deviation: initialDeviation}
+	}
+	rating := *r
+	days := now.Sub(r.LastPlayed).Hours() / 24
+	rating.Deviation = math.Min(math.Sqrt(r.Deviation*r.Deviation+deviationGrowth*deviationGrowth*days), initialDeviation)
+	return rating
+}
+
+// record updates the ratings of the sessions after a game, the
+// winner won against each other player
+func (s *ratingStore) record(winner string, losers []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	before := map[string]Rating{winner: s.current(winner, now)}
+	for _, loser := range losers {
+		before[loser] = s.current(loser, now)
+	}
+
+	winnerRating := before[winner]
+	opponents := make([]Rating, len(losers))
+	scores := make([]float64, len(losers))
+	for i, loser := range losers {
+		opponents[i] = before[loser]
+		scores[i] = 1
+		s.update(loser, glicko(before[loser], []Rating{winnerRating}, []float64{0}), now)
+	}
+	s.update(winner, glicko(winnerRating, opponents, scores), now)
+
+	for session, old := range before {
+		log.Printf("Rating of session %s: %.0f±%.0f -> %.0f±%.0f",
+			sessionLabel(session), old.Rating, old.Deviation, s.sessions[session].Rating, s.sessions[session].Deviation)
+	}
+	s.save()
+}
+
+func (s *ratingStore) update(session string, rating Rating, now time.Time) {
+	rating.Games++
+	rating.LastPlayed = now
+	s.sessions[session] = &rating
+}
+
+// save writes the ratings to the file of the store,
+// it has to be called with the lock held
+func (s *ratingStore) save() {
+	if s.path == "" {
+		return
+	}
+	data, err := json.Marshal(s.sessions)
+	if err != nil {
+		log.Printf("Could not encode the ratings, %v", err)
+		return
+	}
+	tmp := s.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		log.Printf("Could not save the ratings, %v", err)
+		return
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		log.Printf("Could not save the ratings, %v", err)
+	}
+}
+
+// glicko returns the rating after a rating period with the games against
+// the opponents, with a score of 1 for a win and 0 for a loss
+func glicko(r Rating, opponents []Rating, scores []float64) Rating {
+	var dInv, delta float64
+	for i, o := range opponents {
+		g := glickoG(o.Deviation)
+		e := expectedScore(r, o)
+		dInv += g * g * e * (1 - e)
+		delta += g * (scores[i] - e)
+	}
+	dInv *= glickoQ * glickoQ
+	variance := 1 / (1/(r.Deviation*r.Deviation) + dInv)
+	r.Rating += glickoQ * variance * delta
+	r.Deviation = math.Max(math.Sqrt(variance), minDeviation)
+	return r
+}
+
+func glickoG(deviation float64) float64 {
+	return 1 / math.Sqrt(1+3*glickoQ*glickoQ*deviation*deviation/(math.Pi*math.Pi))
+}
+
+// expectedScore is the probability of r winning against the opponent
+func expectedScore(r, opponent Rating) float64 {
+	return 1 / (1 + math.Pow(10, -glickoG(opponent.Deviation)*(r.Rating-opponent.Rating)/400))
+}
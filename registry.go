@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// GameRegistry finds the running games. The games themselves always run
+// on the server which created them, the registry of a server sharing its
+// games with others also knows where the games of the others are hosted.
+type GameRegistry interface {
+	// Add registers a game of this server
+	Add(g *Game)
+	// Get returns the game of this server with the id, or nil
+	Get(id string) *Game
+	// Remove unregisters a game of this server when it is over
+	Remove(id string)
+	// List shows the game in the game browser, its settings must not
+	// change afterwards. Games of private rooms are never listed.
+	List(g *Game)
+	// Open returns the listed games with free seats which match the
+	// filter, the oldest first
+	Open(filter gameFilter, now time.Time) []GameListing
+	// Host returns the base URL of the server hosting the game,
+	// when it is hosted by another server
+	Host(id string) (string, bool)
+}
+
+// gameRegistry holds the games of this server by their ids. It is safe for
+// concurrent use by the request handlers and the games themselves.
+type gameRegistry struct {
+	mu    sync.RWMutex
+	games map[string]*Game
+	// games anyone may join, which are shown in the game browser
+	listed map[string]bool
+}
+
+var activeGames GameRegistry = newGameRegistry()
+
+func newGameRegistry() *gameRegistry {
+	return &gameRegistry{games: make(map[string]*Game), listed: make(map[string]bool)}
+}
+
+// Add implements GameRegistry
+func (r *gameRegistry) Add(g *Game) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.games[g.id] = g
+}
+
+// Get implements GameRegistry
+func (r *gameRegistry) Get(id string) *Game {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.games[id]
+}
+
+// Remove implements GameRegistry
+func (r *gameRegistry) Remove(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.games, id)
+	delete(r.listed, id)
+}
+
+// List implements GameRegistry
+func (r *gameRegistry) List(g *Game) {
+	if g.private {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.games[g.id] == g {
+		r.listed[g.id] = true
+	}
+}
+
+// Open implements GameRegistry
+func (r *gameRegistry) Open(filter gameFilter, now time.Time) []GameListing {
+	return filterListings(r.listings(now), filter)
+}
+
+// Host implements GameRegistry, all games are hosted by this server
+func (r *gameRegistry) Host(id string) (string, bool) {
+	return "", false
+}
+
+// listings describes the listed games of this server
+func (r *gameRegistry) listings(now time.Time) []GameListing {
+	r.mu.RLock()
+	games := make([]*Game, 0, len(r.listed))
+	for id := range r.listed {
+		games = append(games, r.games[id])
+	}
+	r.mu.RUnlock()
+
+	listings := make([]GameListing, len(games))
+	for i, g := range games {
+		listings[i] = g.listing(now)
+	}
+	return listings
+}
+
+// gameFilter selects the open games of the game browser
+type gameFilter struct {
+	// number of players of the game, 0 matches any
+	capacity int
+	// minimal number of free seats
+	free int
+	// whether the game has bots, nil matches both
+	bots *bool
+}
+
+// GameMap describes the board of a game
+type GameMap struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// GameSettings are the settings a game was created with
+type GameSettings struct {
+	Capacity int `json:"capacity"`
+	Bots     int `json:"bots"`
+}
+
+// GameListing is an open game in the game browser
+type GameListing struct {
+	ID        string       `json:"id"`
+	Link      string       `json:"link"`
+	Map       GameMap      `json:"map"`
+	Settings  GameSettings `json:"settings"`
+	Players   int          `json:"players"`
+	FreeSeats int          `json:"freeSeats"`
+	// seconds since the game was created
+	Age int `json:"age"`
+
+	createdAt time.Time
+}
+
+// filterListings returns the listings with free seats which
+// match the filter, the oldest first
+func filterListings(listings []GameListing, filter gameFilter) []GameListing {
+	open := make([]GameListing, 0, len(listings))
+	for _, listing := range listings {
+		if listing.FreeSeats < 1 || listing.FreeSeats < filter.free {
+			continue
+		}
+		if filter.capacity != 0 && listing.Settings.Capacity != filter.capacity {
+			continue
+		}
+		if filter.bots != nil && (listing.Settings.Bots > 0) != *filter.bots {
+			continue
+		}
+		open = append(open, listing)
+	}
+	sort.Slice(open, func(i, j int) bool {
+		return open[i].createdAt.Before(open[j].createdAt)
+	})
+	return open
+}
+
+// listing describes the game for the game browser
+func (g *Game) listing(now time.Time) GameListing {
+	g.seatsMu.Lock()
+	seats := g.seats
+	g.seatsMu.Unlock()
+	return GameListing{
+		ID:        g.id,
+		Link:      fmt.Sprintf("/g/%s", g.id),
+		Map:       GameMap{Width: width, Height: height},
+		Settings:  GameSettings{Capacity: g.capacity, Bots: g.bots},
+		Players:   seats,
+		FreeSeats: g.capacity - seats,
+		Age:       int(now.Sub(g.createdAt).Seconds()),
+		createdAt: g.createdAt,
+	}
+}
@@ -0,0 +1,439 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/lazareviczoran/blaster-twister/protocol"
+	"github.com/lazareviczoran/blaster-twister/store"
+)
+
+const (
+	// Time the server running the shared lobby holds it without renewing
+	leaseTTL = 5 * time.Second
+	// Interval of renewing the lease, and of trying to take it over
+	leaseRenewInterval = 2 * time.Second
+	// Additional time for a remote candidate to answer a ping, which
+	// goes through the store and its server both ways
+	relayCheckDelay = 300 * time.Millisecond
+)
+
+// lobbyEvent is something a candidate did on the server it is connected
+// to, which is passed to the server running the shared lobby
+type lobbyEvent struct {
+	// "enqueue", "join", "rejoin", "queue", "cancel", "disconnect",
+	// "confirm", "decline" or "pong"
+	Kind    string                  `json:"kind"`
+	Key     string                  `json:"key"`
+	Node    string                  `json:"node,omitempty"`
+	Session string                  `json:"session,omitempty"`
+	Rating  *Rating                 `json:"rating,omitempty"`
+	Profile *protocol.PlayerProfile `json:"profile,omitempty"`
+	// party code to join, empty creates a new party
+	Code string `json:"code,omitempty"`
+	// whether the candidate rejoining its party leads it
+	Leader bool `json:"leader,omitempty"`
+	// round trip time of an answer to a ping, in milliseconds
+	RTT int64 `json:"rtt,omitempty"`
+}
+
+// lobbyRelay is the Matchmaker of the servers sharing their lobby. A single
+// server at a time holds the lease on the lobby and runs it, with a remote
+// candidate standing in for each candidate of the cluster. The relay of
+// each server passes what its candidates do to the lobby and the messages
+// of the lobby back to them. When another server takes over the lobby, the
+// relays register their candidates again.
+type lobbyRelay struct {
+	cluster *Cluster
+	// serializes the events, so the lobby gets them in order
+	mu sync.Mutex
+	// candidates connected to this server by their keys
+	local map[string]*localCandidate
+}
+
+// localCandidate is a candidate connected to this server,
+// with what the relay knows about it from the lobby
+type localCandidate struct {
+	*Candidate
+	// whether the candidate came alone
+	alone bool
+	// code of the party of the candidate, and whether it leads the party
+	party  string
+	leader bool
+}
+
+func newLobbyRelay(cl *Cluster) *lobbyRelay {
+	r := &lobbyRelay{cluster: cl, local: make(map[string]*localCandidate)}
+	cl.relay = r
+	return r
+}
+
+// Enqueue implements Matchmaker
+func (r *lobbyRelay) Enqueue(c *Candidate) {
+	r.register(&localCandidate{Candidate: c, alone: true}, lobbyEvent{Kind: "enqueue"})
+}
+
+// JoinParty implements Matchmaker
+func (r *lobbyRelay) JoinParty(c *Candidate, code string) {
+	r.register(&localCandidate{Candidate: c}, lobbyEvent{Kind: "join", Code: code})
+}
+
+// Queue implements Matchmaker
+func (r *lobbyRelay) Queue(c *Candidate) {
+	r.push(c, lobbyEvent{Kind: "queue"})
+}
+
+// Cancel implements Matchmaker
+func (r *lobbyRelay) Cancel(c *Candidate) {
+	r.push(c, lobbyEvent{Kind: "cancel"})
+}
+
+// Disconnect implements Matchmaker
+func (r *lobbyRelay) Disconnect(c *Candidate) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if lc := r.local[c.key]; lc == nil || lc.Candidate != c {
+		return
+	}
+	delete(r.local, c.key)
+	r.pushLocked(lobbyEvent{Kind: "disconnect", Key: c.key})
+}
+
+// Confirm implements Matchmaker
+func (r *lobbyRelay) Confirm(c *Candidate) {
+	r.push(c, lobbyEvent{Kind: "confirm"})
+}
+
+// Decline implements Matchmaker
+func (r *lobbyRelay) Decline(c *Candidate) {
+	r.push(c, lobbyEvent{Kind: "decline"})
+}
+
+// Pong implements Matchmaker, the answer goes to the remote
+// candidate the lobby is probing
+func (r *lobbyRelay) Pong(c *Candidate, rtt time.Duration) {
+	r.push(c, lobbyEvent{Kind: "pong", RTT: int64(rtt / time.Millisecond)})
+}
+
+// register adds the candidate to the shared lobby, candidates
+// are sent away while the store is not available
+func (r *lobbyRelay) register(lc *localCandidate, ev lobbyEvent) {
+	r.mu.Lock()
+	select {
+	case <-lc.done:
+		r.mu.Unlock()
+		return
+	default:
+	}
+	r.local[lc.key] = lc
+	err := r.pushLocked(r.identify(lc.Candidate, ev))
+	if err != nil {
+		delete(r.local, lc.key)
+	}
+	r.mu.Unlock()
+	if err != nil {
+		lc.Leave(protocol.Error{Error: "The lobby is not available, try again later"})
+	}
+}
+
+// identify adds what the lobby needs to know about the candidate to the event
+func (r *lobbyRelay) identify(c *Candidate, ev lobbyEvent) lobbyEvent {
+	profile := c.profile()
+	rating := c.rating
+	ev.Key = c.key
+	ev.Node = r.cluster.self.ID
+	ev.Session = c.session
+	ev.Rating = &rating
+	ev.Profile = &profile
+	return ev
+}
+
+// reregister registers the candidates of this server with a lobby which
+// took over from another server. Candidates which came alone are queued
+// again and the members of parties rejoin them, candidates which were
+// about to join a party start over.
+func (r *lobbyRelay) reregister() {
+	r.mu.Lock()
+	var lost []*Candidate
+	for key, lc := range r.local {
+		var ev lobbyEvent
+		switch {
+		case lc.alone:
+			ev = lobbyEvent{Kind: "enqueue"}
+		case lc.party != "":
+			ev = lobbyEvent{Kind: "rejoin", Code: lc.party, Leader: lc.leader}
+		default:
+			delete(r.local, key)
+			lost = append(lost, lc.Candidate)
+			continue
+		}
+		if err := r.pushLocked(r.identify(lc.Candidate, ev)); err != nil {
+			delete(r.local, key)
+			lost = append(lost, lc.Candidate)
+		}
+	}
+	r.mu.Unlock()
+	log.Printf("Registered the candidates with the new lobby")
+	for _, c := range lost {
+		c.Leave(protocol.Error{Error: "The lobby was restarted, try again"})
+	}
+}
+
+// push passes the event of a registered candidate to the lobby
+func (r *lobbyRelay) push(c *Candidate, ev lobbyEvent) {
+	ev.Key = c.key
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if lc := r.local[c.key]; lc == nil || lc.Candidate != c {
+		return
+	}
+	r.pushLocked(ev)
+}
+
+func (r *lobbyRelay) pushLocked(ev lobbyEvent) error {
+	data, err := json.Marshal(ev)
+	if err == nil {
+		err = r.cluster.store.RPush(lobbyKey, string(data))
+	}
+	if err != nil {
+		log.Printf("Could not pass %s of candidate %s to the lobby, %v", ev.Kind, ev.Key, err)
+	}
+	return err
+}
+
+// deliver sends the message of the lobby to the candidate connected to this server
+func (r *lobbyRelay) deliver(key string, data []byte) {
+	msg, err := protocol.Decode(data)
+	if err != nil {
+		log.Printf("Invalid message for candidate %s, %v", key, err)
+		return
+	}
+	r.mu.Lock()
+	lc := r.local[key]
+	if lc != nil {
+		// the party is remembered in case another server takes over the lobby
+		if party, ok := msg.(*protocol.Party); ok {
+			lc.party = party.Code
+			lc.leader = false
+			for _, member := range party.Members {
+				if member.ID == party.You {
+					lc.leader = member.Leader
+				}
+			}
+		}
+	}
+	r.mu.Unlock()
+	if lc == nil {
+		return
+	}
+	switch msg := msg.(type) {
+	case *protocol.Ping:
+		// the round trip is measured with the clock of this server
+		msg.Time = unixMillis(time.Now())
+		lc.Send(msg)
+	case *protocol.Queued:
+		lc.Notify(msg)
+	default:
+		lc.Send(msg)
+	}
+}
+
+// close closes the connection of the candidate connected to this server
+func (r *lobbyRelay) close(key string) {
+	r.mu.Lock()
+	lc := r.local[key]
+	r.mu.Unlock()
+	if lc != nil {
+		lc.Close()
+	}
+}
+
+// run runs the shared lobby whenever this server gets the lease on it
+func (r *lobbyRelay) run() {
+	inbox := store.NewClient(r.cluster.addr)
+	for {
+		// each term of a lease has a value of its own, so the servers notice
+		// when this server loses the lease and takes it over again
+		term := r.cluster.self.ID + ":" + randToken()
+		ok, err := r.cluster.store.SetNX(lobbyLeaseKey, term, leaseTTL)
+		if err != nil {
+			log.Printf("Could not take the lease on the lobby, %v", err)
+		}
+		if !ok {
+			time.Sleep(leaseRenewInterval)
+			continue
+		}
+		log.Printf("Running the shared lobby")
+		r.lead(inbox, term)
+		log.Printf("Lost the lease on the shared lobby")
+	}
+}
+
+// watch registers the candidates of this server again
+// whenever another term of the lease on the lobby starts
+func (r *lobbyRelay) watch() {
+	ticker := time.NewTicker(leaseRenewInterval)
+	defer ticker.Stop()
+	term := ""
+	for range ticker.C {
+		current, err := r.cluster.store.Get(lobbyLeaseKey)
+		if err != nil || current == term {
+			// without a lease the candidates wait for the next lobby
+			continue
+		}
+		if term != "" {
+			r.reregister()
+		}
+		term = current
+	}
+}
+
+// lead runs the lobby with the events of the candidates
+// of all servers until the lease is lost
+func (r *lobbyRelay) lead(inbox *store.Client, term string) {
+	l := newLobby(*botBackfillWait, *readyTimeout, *queueTimeout)
+	l.host = r.cluster.hostGame
+	go l.run()
+	remote := make(map[string]*Candidate)
+	defer func() {
+		// the servers register the candidates with the next lobby
+		l.stop()
+		for key, c := range remote {
+			delete(remote, key)
+			close(c.done)
+		}
+	}()
+
+	renewed := time.Now()
+	for {
+		if now := time.Now(); now.Sub(renewed) >= leaseRenewInterval {
+			if !r.renew(term) {
+				return
+			}
+			renewed = now
+		}
+		data, err := inbox.BLPop(lobbyKey, time.Second)
+		if err == store.ErrNil {
+			continue
+		}
+		if err != nil {
+			log.Printf("Could not read the lobby events, %v", err)
+			time.Sleep(time.Second)
+			continue
+		}
+		var ev lobbyEvent
+		if err := json.Unmarshal([]byte(data), &ev); err != nil {
+			log.Printf("Invalid lobby event %s, %v", data, err)
+			continue
+		}
+
+		if ev.Kind == "enqueue" || ev.Kind == "join" || ev.Kind == "rejoin" {
+			// a candidate registered before the lobby started may register again
+			if remote[ev.Key] != nil {
+				continue
+			}
+			c := r.newRemoteCandidate(l, ev)
+			remote[ev.Key] = c
+			switch ev.Kind {
+			case "enqueue":
+				l.Enqueue(c)
+			case "join":
+				l.JoinParty(c, ev.Code)
+			case "rejoin":
+				l.rejoinParty(c, ev.Code, ev.Leader)
+			}
+			continue
+		}
+		c := remote[ev.Key]
+		if c == nil {
+			continue
+		}
+		switch ev.Kind {
+		case "queue":
+			l.Queue(c)
+		case "cancel":
+			l.Cancel(c)
+		case "confirm":
+			l.Confirm(c)
+		case "decline":
+			l.Decline(c)
+		case "pong":
+			c.answer(time.Duration(ev.RTT) * time.Millisecond)
+		case "disconnect":
+			delete(remote, ev.Key)
+			close(c.done)
+			l.Disconnect(c)
+		}
+	}
+}
+
+// renew extends the term of the lease on the lobby, it returns whether
+// this server still holds it
+func (r *lobbyRelay) renew(term string) bool {
+	ok, err := r.cluster.store.ExpireIfEquals(lobbyLeaseKey, term, leaseTTL)
+	if err != nil {
+		log.Printf("Could not renew the lease on the lobby, %v", err)
+	}
+	return ok
+}
+
+// newRemoteCandidate creates the stand-in of a candidate connected to
+// another server, whose messages are passed to that server
+func (r *lobbyRelay) newRemoteCandidate(l *Lobby, ev lobbyEvent) *Candidate {
+	c := &Candidate{
+		send:          make(chan protocol.Message, 8),
+		receive:       make(chan time.Duration, 1),
+		done:          make(chan struct{}),
+		lobby:         l,
+		session:       ev.Session,
+		checkTimeout:  candidateCheckTimeout + relayCheckDelay,
+		key:           ev.Key,
+		node:          ev.Node,
+		remoteProfile: ev.Profile,
+	}
+	if ev.Rating != nil {
+		c.rating = *ev.Rating
+	}
+	go r.forward(c)
+	return c
+}
+
+// forward passes the messages of the lobby to the server of the remote
+// candidate, until the candidate disconnects
+func (r *lobbyRelay) forward(c *Candidate) {
+	closed := false
+	for {
+		select {
+		case msg := <-c.send:
+			// messages after closing the connection are dropped
+			if closed {
+				continue
+			}
+			closed = msg == nil
+			r.sendTo(c, msg)
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// sendTo puts the message for the remote candidate into the mailbox
+// of its server, nil closes the connection
+func (r *lobbyRelay) sendTo(c *Candidate, msg protocol.Message) {
+	if msg == nil {
+		if err := r.cluster.post(c.node, nodeMessage{Kind: "close", Key: c.key}); err != nil {
+			log.Printf("Could not close candidate %s, %v", c.key, err)
+		}
+		return
+	}
+	data, err := protocol.Encode(msg)
+	if err != nil {
+		log.Printf("Could not encode %s message, %v", msg.MessageType(), err)
+		return
+	}
+	if err := r.cluster.post(c.node, nodeMessage{Kind: "send", Key: c.key, Message: data}); err != nil {
+		log.Printf("Could not send %s message to candidate %s, %v", msg.MessageType(), c.key, err)
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/lazareviczoran/blaster-twister/protocol"
+	"github.com/lazareviczoran/blaster-twister/store"
+	"github.com/lazareviczoran/blaster-twister/store/storetest"
+)
+
+// deliverMail passes the messages in the mailbox of the server to its
+// relay like Cluster.receive, until the returned function is called
+func deliverMail(t *testing.T, r *lobbyRelay) func() {
+	mailbox := store.NewClient(r.cluster.addr)
+	quit := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-quit:
+				return
+			default:
+			}
+			data, err := mailbox.BLPop(mailboxPrefix+r.cluster.self.ID, 100*time.Millisecond)
+			if err != nil {
+				continue
+			}
+			var msg nodeMessage
+			if err := json.Unmarshal([]byte(data), &msg); err != nil {
+				t.Errorf("Invalid message %s in the mailbox, %v", data, err)
+				continue
+			}
+			switch msg.Kind {
+			case "send":
+				r.deliver(msg.Key, msg.Message)
+			case "close":
+				r.close(msg.Key)
+			}
+		}
+	}()
+	return func() {
+		close(quit)
+		<-stopped
+		mailbox.Close()
+	}
+}
+
+// awaitParty returns the first state of the party of the client with the number of members
+func awaitParty(t *testing.T, tc *testClient, members int) *protocol.Party {
+	t.Helper()
+	for {
+		msg := tc.next(t)
+		party, ok := msg.(*protocol.Party)
+		if !ok {
+			t.Fatalf("Got %#v, want the party", msg)
+		}
+		if len(party.Members) == members {
+			return party
+		}
+	}
+}
+
+func encodeMessage(t *testing.T, msg protocol.Message) []byte {
+	data, err := protocol.Encode(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestRelayFailover(t *testing.T) {
+	s, err := storetest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	r := newLobbyRelay(newCluster(s.Addr(), clusterNode{ID: "a", URL: "http://a"}))
+	defer r.cluster.store.Close()
+
+	// candidates registered with the lobby of a server which is gone
+	solo, leader, friend, joining := newTestClient(r, false), newTestClient(r, false), newTestClient(r, false), newTestClient(r, false)
+	r.Enqueue(solo.Candidate)
+	r.JoinParty(leader.Candidate, "")
+	r.JoinParty(friend.Candidate, "ABCD")
+	r.JoinParty(joining.Candidate, "ABCD")
+	members := []protocol.PartyMember{{ID: 1, Leader: true}, {ID: 2}}
+	r.deliver(leader.key, encodeMessage(t, protocol.Party{Code: "ABCD", You: 1, Members: members}))
+	r.deliver(friend.key, encodeMessage(t, protocol.Party{Code: "ABCD", You: 2, Members: members}))
+	awaitParty(t, leader, 2)
+	awaitParty(t, friend, 2)
+	if _, err := r.cluster.store.Do("DEL", lobbyKey); err != nil {
+		t.Fatal(err)
+	}
+
+	r.reregister()
+	if msg, ok := joining.next(t).(protocol.Error); !ok {
+		t.Fatalf("Got %#v, want an error for the candidate joining a party", msg)
+	}
+	if msg := joining.next(t); msg != nil {
+		t.Fatalf("Got %#v, want the connection closed", msg)
+	}
+	events := make(map[string]lobbyEvent)
+	for _, data := range s.List(lobbyKey) {
+		var ev lobbyEvent
+		if err := json.Unmarshal([]byte(data), &ev); err != nil {
+			t.Fatal(err)
+		}
+		events[ev.Key] = ev
+	}
+	want := map[string]lobbyEvent{
+		solo.key:   {Kind: "enqueue"},
+		leader.key: {Kind: "rejoin", Code: "ABCD", Leader: true},
+		friend.key: {Kind: "rejoin", Code: "ABCD"},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("Registered %d candidates, want %d", len(events), len(want))
+	}
+	for key, w := range want {
+		ev := events[key]
+		if ev.Kind != w.Kind || ev.Code != w.Code || ev.Leader != w.Leader || ev.Node != "a" {
+			t.Errorf("Registered %+v, want %+v", ev, w)
+		}
+	}
+
+	// the new lobby restores the party with its leader
+	stop := deliverMail(t, r)
+	defer stop()
+	term := "a:1"
+	s.Set(lobbyLeaseKey, term)
+	inbox := store.NewClient(s.Addr())
+	defer inbox.Close()
+	led := make(chan struct{})
+	go func() {
+		r.lead(inbox, term)
+		close(led)
+	}()
+	for _, tc := range []*testClient{leader, friend} {
+		party := awaitParty(t, tc, 2)
+		leads := party.Members[0].ID == party.You
+		if party.Code != "ABCD" || leads != (tc == leader) {
+			t.Errorf("Got %+v, want the party restored with its leader", party)
+		}
+	}
+	r.Queue(leader.Candidate)
+	if party := awaitParty(t, friend, 2); !party.Queued {
+		t.Errorf("Got %+v, want the restored party queued by its leader", party)
+	}
+	if n := len(solo.messages); n != 0 {
+		t.Errorf("The candidate which came alone got %d messages, want it waiting in the queue", n)
+	}
+
+	// the lobby stops once another server takes over the lease
+	s.Set(lobbyLeaseKey, "b:1")
+	select {
+	case <-led:
+	case <-time.After(leaseRenewInterval + 2*time.Second):
+		t.Fatal("The lobby kept running without the lease")
+	}
+	if value, _ := s.Get(lobbyLeaseKey); value != "b:1" {
+		t.Errorf("The lease is %q, want it kept by the other server", value)
+	}
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/lazareviczoran/blaster-twister/protocol"
+)
+
+// Time the players have to accept a rematch
+const rematchTimeout = 30 * time.Second
+
+// rematches holds the open rematch offers by the ids of the finished games
+var (
+	rematches   = make(map[string]*Rematch)
+	rematchesMu sync.Mutex
+)
+
+// series counts the wins of each seat over a game and its rematches
+type series struct {
+	wins  []int
+	games int
+}
+
+func newSeries(capacity int) *series {
+	return &series{wins: make([]int, capacity)}
+}
+
+func (s *series) record(winner int) {
+	s.wins[winner]++
+	s.games++
+}
+
+func (s *series) copy() *series {
+	return &series{wins: append([]int(nil), s.wins...), games: s.games}
+}
+
+// Rematch is the offer to play a finished game again with the same players,
+// seats and settings. The game is created once all human players accepted.
+type Rematch struct {
+	gameID        string
+	capacity      int
+	botDifficulty Difficulty
+	adaptive      bool
+	// session of the player whose skill sets the difficulty in adaptive games
+	session string
+	rated   bool
+	private bool
+	series  *series
+	// personalities of the bots by their ids
+	bots map[int]Personality
+	// ids of the human players by their seat tokens
+	humans   map[string]int
+	mu       sync.Mutex
+	voters   map[int]*roomMember
+	accepted map[int]bool
+	done     bool
+	timer    *time.Timer
+}
+
+// offerRematch opens a rematch offer for the finished game and tells the
+// players about it, there is no rematch when a player forfeited
+func (g *Game) offerRematch() {
+	if len(g.forfeited) > 0 {
+		return
+	}
+	r := &Rematch{
+		gameID:        g.id,
+		capacity:      g.capacity,
+		botDifficulty: g.botDifficulty,
+		adaptive:      g.adaptive,
+		rated:         g.rated,
+		private:       g.private,
+		series:        g.series.copy(),
+		bots:          make(map[int]Personality),
+		humans:        make(map[string]int),
+		voters:        make(map[int]*roomMember),
+		accepted:      make(map[int]bool),
+	}
+	for _, p := range g.players {
+		switch p := p.(type) {
+		case *Human:
+			r.humans[p.seatToken] = p.id
+			if r.adaptive && p.session != "" {
+				r.session = p.session
+			}
+		case *Bot:
+			r.bots[p.id] = p.personality
+		}
+	}
+	if len(r.humans) == 0 {
+		return
+	}
+	rematchesMu.Lock()
+	rematches[g.id] = r
+	rematchesMu.Unlock()
+	r.mu.Lock()
+	r.timer = time.AfterFunc(rematchTimeout, func() {
+		r.cancel("The rematch offer expired")
+	})
+	r.mu.Unlock()
+	g.sendToAll(r.offer())
+}
+
+func findRematch(gameID string) *Rematch {
+	rematchesMu.Lock()
+	defer rematchesMu.Unlock()
+	return rematches[gameID]
+}
+
+// seat returns the id of the player holding the seat token
+func (r *Rematch) seat(token string) (int, error) {
+	id, ok := r.humans[token]
+	if !ok {
+		return 0, errors.New("Unknown seat token")
+	}
+	return id, nil
+}
+
+func (r *Rematch) offer() protocol.RematchOffer {
+	offer := protocol.RematchOffer{Timeout: int(rematchTimeout.Seconds()), Wins: r.series.wins, Games: r.series.games}
+	for id := range r.accepted {
+		offer.Accepted = append(offer.Accepted, id)
+	}
+	return offer
+}
+
+// join adds the connection of the player, which can then accept or decline
+func (r *Rematch) join(conn *websocket.Conn, id int) {
+	r.mu.Lock()
+	if r.done {
+		r.mu.Unlock()
+		rejectConnection(conn, "The rematch offer is closed")
+		return
+	}
+	if old := r.voters[id]; old != nil {
+		close(old.send)
+	}
+	m := &roomMember{id: id, conn: conn, send: make(chan protocol.Message, 16)}
+	r.voters[id] = m
+	m.sendMessage(r.offer())
+	r.mu.Unlock()
+
+	go m.writePump()
+	go r.readPump(m)
+}
+
+func (r *Rematch) readPump(m *roomMember) {
+	defer func() {
+		m.conn.Close()
+		r.mu.Lock()
+		current := r.voters[m.id] == m
+		r.mu.Unlock()
+		// leaving before the new game is created declines the rematch
+		if current {
+			r.cancel("The rematch was declined")
+		}
+	}()
+	m.conn.SetReadLimit(maxMessageSize)
+	m.conn.SetReadDeadline(time.Now().Add(pongWait))
+	m.conn.SetPongHandler(func(string) error { m.conn.SetReadDeadline(time.Now().Add(pongWait)); return nil })
+	for {
+		_, message, err := m.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+				log.Printf("error: %v", err)
+			}
+			return
+		}
+		msg, err := protocol.Decode(message)
+		rematch, ok := msg.(*protocol.Rematch)
+		if !ok {
+			log.Printf("Invalid rematch message %s, %v", message, err)
+			continue
+		}
+		if !rematch.Accept {
+			return
+		}
+		r.accept(m.id)
+	}
+}
+
+// accept records that the player accepted and starts the
+// rematch once all human players did
+func (r *Rematch) accept(id int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.done {
+		return
+	}
+	r.accepted[id] = true
+	if len(r.accepted) < len(r.humans) {
+		offer := r.offer()
+		for _, m := range r.voters {
+			m.sendMessage(offer)
+		}
+		return
+	}
+	if err := r.start(); err != nil {
+		log.Printf("Could not start the rematch of game %s, %v", r.gameID, err)
+		r.close(protocol.Error{Error: err.Error()})
+	}
+}
+
+// start creates the new game with the same seats, the humans get tokens
+// of their reserved seats. It has to be called with the lock held.
+func (r *Rematch) start() error {
+	game, err := createGame(r.capacity)
+	if err != nil {
+		return err
+	}
+	game.botDifficulty = r.botDifficulty
+	if r.adaptive && r.session != "" {
+		// the skill of the player changed with the result of the last game
+		game.botDifficulty = skills.difficulty(r.session)
+	}
+	game.adaptive = r.adaptive
+	game.rated = r.rated
+	game.private = r.private
+	game.series = r.series.copy()
+
+	game.seatsMu.Lock()
+	// all seats belong to the players of the series
+	game.seats = game.capacity
+	game.reserved = true
+	seats := make(map[int]string, len(r.humans))
+	for _, id := range r.humans {
+		seat := newSeatToken(game.id, id)
+		game.invited[seat] = id
+		seats[id] = seat
+	}
+	game.seatsMu.Unlock()
+	for id, personality := range r.bots {
+		addBot(game, id, personality)
+	}
+
+	log.Printf("Rematch of game %s started game %s", r.gameID, game.id)
+	r.done = true
+	r.timer.Stop()
+	for id, m := range r.voters {
+		m.sendMessage(protocol.Start{Game: game.id, Seat: seats[id]})
+		close(m.send)
+	}
+	r.voters = nil
+	r.remove()
+	return nil
+}
+
+// cancel closes the offer without a rematch
+func (r *Rematch) cancel(reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.done {
+		return
+	}
+	log.Printf("Rematch of game %s cancelled: %s", r.gameID, reason)
+	r.close(protocol.Error{Error: reason})
+}
+
+// close tells the connected players why the offer is closed,
+// it has to be called with the lock held
+func (r *Rematch) close(msg protocol.Message) {
+	r.done = true
+	r.timer.Stop()
+	for _, m := range r.voters {
+		m.sendMessage(msg)
+		close(m.send)
+	}
+	r.voters = nil
+	r.remove()
+}
+
+func (r *Rematch) remove() {
+	rematchesMu.Lock()
+	defer rematchesMu.Unlock()
+	if rematches[r.gameID] == r {
+		delete(rematches, r.gameID)
+	}
+}
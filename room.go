@@ -0,0 +1,327 @@
+package main
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/lazareviczoran/blaster-twister/protocol"
+)
+
+// Characters of the room codes, without the ones which are easily confused
+const roomCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+const roomCodeLength = 6
+
+// Rooms without members are closed after this time
+const roomTimeout = 10 * time.Minute
+
+// rooms holds the private rooms by their codes
+var (
+	rooms   = make(map[string]*Room)
+	roomsMu sync.Mutex
+)
+
+// Room is a private waiting room, which is only reachable through its code.
+// The host chooses the settings and starts the game once all members are
+// ready, the game is never offered to public matchmaking.
+type Room struct {
+	code        string
+	hostSession string
+	mu          sync.Mutex
+	members     []*roomMember
+	nextID      int
+	settings    protocol.RoomSettings
+	started     bool
+	closeTimer  *time.Timer
+}
+
+type roomMember struct {
+	id      int
+	session string
+	host    bool
+	ready   bool
+	conn    *websocket.Conn
+	send    chan protocol.Message
+}
+
+// createRoom opens a new private room hosted by the session
+func createRoom(hostSession string) *Room {
+	roomsMu.Lock()
+	defer roomsMu.Unlock()
+	code := newRoomCode()
+	for rooms[code] != nil {
+		code = newRoomCode()
+	}
+	room := &Room{code: code, hostSession: hostSession}
+	room.closeTimer = time.AfterFunc(roomTimeout, room.closeIfEmpty)
+	rooms[code] = room
+	log.Printf("Created private room %s", code)
+	return room
+}
+
+func newRoomCode() string {
+	b := make([]byte, roomCodeLength)
+	if _, err := rand.Read(b); err != nil {
+		log.Fatalf("Could not generate a room code, %v", err)
+	}
+	for i := range b {
+		b[i] = roomCodeAlphabet[int(b[i])%len(roomCodeAlphabet)]
+	}
+	return string(b)
+}
+
+func findRoom(code string) *Room {
+	roomsMu.Lock()
+	defer roomsMu.Unlock()
+	return rooms[code]
+}
+
+func (r *Room) link() string {
+	return fmt.Sprintf("/r/%s", r.code)
+}
+
+// join adds the connection as a new member, the first member
+// with the session of the creator becomes the host
+func (r *Room) join(conn *websocket.Conn, session string) {
+	r.mu.Lock()
+	if r.started || len(r.members) >= maxPlayers {
+		r.mu.Unlock()
+		rejectConnection(conn, "The room is full")
+		return
+	}
+	m := &roomMember{id: r.nextID, session: session, conn: conn, send: make(chan protocol.Message, 16)}
+	r.nextID++
+	if r.host() == nil && session != "" && session == r.hostSession {
+		m.host = true
+	}
+	r.members = append(r.members, m)
+	r.closeTimer.Stop()
+	r.broadcastState()
+	r.mu.Unlock()
+
+	go m.writePump()
+	go r.readPump(m)
+}
+
+// leave removes the member, the longest waiting member becomes
+// the host when the host leaves
+func (r *Room) leave(m *roomMember) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, member := range r.members {
+		if member == m {
+			r.members = append(r.members[:i], r.members[i+1:]...)
+			close(m.send)
+			break
+		}
+	}
+	if len(r.members) == 0 {
+		r.closeTimer.Reset(roomTimeout)
+		return
+	}
+	if m.host && !r.started {
+		r.members[0].host = true
+	}
+	r.broadcastState()
+}
+
+func (r *Room) closeIfEmpty() {
+	r.mu.Lock()
+	empty := len(r.members) == 0
+	r.mu.Unlock()
+	if empty {
+		r.close()
+	}
+}
+
+func (r *Room) close() {
+	roomsMu.Lock()
+	defer roomsMu.Unlock()
+	if rooms[r.code] == r {
+		delete(rooms, r.code)
+		log.Printf("Closed private room %s", r.code)
+	}
+}
+
+func (r *Room) host() *roomMember {
+	for _, m := range r.members {
+		if m.host {
+			return m
+		}
+	}
+	return nil
+}
+
+// broadcastState sends the state of the room to each member,
+// it has to be called with the lock held
+func (r *Room) broadcastState() {
+	members := make([]protocol.RoomMember, len(r.members))
+	for i, m := range r.members {
+		members[i] = protocol.RoomMember{ID: m.id, PlayerProfile: profiles.get(m.session), Host: m.host, Ready: m.ready}
+	}
+	for _, m := range r.members {
+		m.sendMessage(protocol.Room{Code: r.code, Link: r.link(), You: m.id, Members: members, Settings: r.settings})
+	}
+}
+
+// handle applies a message of the member, only the host
+// can change the settings and start the game
+func (r *Room) handle(m *roomMember, msg protocol.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.started {
+		return errors.New("The game has already started")
+	}
+	switch msg := msg.(type) {
+	case *protocol.Ready:
+		m.ready = msg.Ready
+	case *protocol.Profile:
+		if _, err := profiles.set(m.session, msg.PlayerProfile); err != nil {
+			return err
+		}
+	case *protocol.Settings:
+		if !m.host {
+			return errors.New("Only the host can change the settings")
+		}
+		if msg.Bots < 0 || len(r.members)+msg.Bots > maxPlayers {
+			return fmt.Errorf("A game has at most %d players", maxPlayers)
+		}
+		if _, err := r.personalities(msg.RoomSettings); err != nil {
+			return err
+		}
+		r.settings = msg.RoomSettings
+	case *protocol.Start:
+		if !m.host {
+			return errors.New("Only the host can start the game")
+		}
+		return r.start()
+	default:
+		return fmt.Errorf("Unexpected %s message", msg.MessageType())
+	}
+	r.broadcastState()
+	return nil
+}
+
+func (r *Room) personalities(settings protocol.RoomSettings) ([]Personality, error) {
+	return parsePersonalities(strings.Join(settings.Personalities, ","), settings.Bots)
+}
+
+// start creates the game once all members are ready,
+// it has to be called with the lock held
+func (r *Room) start() error {
+	players := len(r.members) + r.settings.Bots
+	if players < 2 || players > maxPlayers {
+		return fmt.Errorf("A game needs between 2 and %d players", maxPlayers)
+	}
+	for _, m := range r.members {
+		if !m.ready && !m.host {
+			return errors.New("Not all players are ready")
+		}
+	}
+	personalities, err := r.personalities(r.settings)
+	if err != nil {
+		return err
+	}
+	game, err := createGame(players)
+	if err != nil {
+		return err
+	}
+	game.private = true
+
+	game.seatsMu.Lock()
+	// all seats belong to the members, nobody else can take them
+	game.seats = game.capacity
+	game.reserved = true
+	seats := make([]string, len(r.members))
+	for id := range r.members {
+		seats[id] = newSeatToken(game.id, id)
+		game.invited[seats[id]] = id
+	}
+	game.seatsMu.Unlock()
+	for i, personality := range personalities {
+		addBot(game, len(r.members)+i, personality)
+	}
+
+	r.started = true
+	log.Printf("Private room %s started game %s", r.code, game.id)
+	for id, m := range r.members {
+		m.sendMessage(protocol.Start{Game: game.id, Seat: seats[id]})
+	}
+	go r.close()
+	return nil
+}
+
+func (r *Room) readPump(m *roomMember) {
+	defer func() {
+		r.leave(m)
+		m.conn.Close()
+	}()
+	m.conn.SetReadLimit(maxMessageSize)
+	m.conn.SetReadDeadline(time.Now().Add(pongWait))
+	m.conn.SetPongHandler(func(string) error { m.conn.SetReadDeadline(time.Now().Add(pongWait)); return nil })
+	for {
+		_, message, err := m.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+				log.Printf("error: %v", err)
+			}
+			return
+		}
+		msg, err := protocol.Decode(message)
+		if err == nil {
+			err = r.handle(m, msg)
+		}
+		if err != nil {
+			r.mu.Lock()
+			m.sendMessage(protocol.Error{Error: err.Error()})
+			r.mu.Unlock()
+		}
+	}
+}
+
+// sendMessage queues the message without blocking, it has to be
+// called with the lock of the room held
+func (m *roomMember) sendMessage(msg protocol.Message) {
+	select {
+	case m.send <- msg:
+	default:
+		log.Printf("Dropping %s message for room member %d", msg.MessageType(), m.id)
+	}
+}
+
+func (m *roomMember) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		m.conn.Close()
+	}()
+	for {
+		select {
+		case msg, ok := <-m.send:
+			m.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if !ok {
+				m.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			data, err := protocol.Encode(msg)
+			if err != nil {
+				log.Printf("Could not encode %s message, %v", msg.MessageType(), err)
+				continue
+			}
+			if err := m.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+				return
+			}
+		case <-ticker.C:
+			m.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := m.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/lazareviczoran/blaster-twister/protocol"
+)
+
+func TestRoomStartReservesTheSeats(t *testing.T) {
+	r := &Room{code: newRoomCode(), settings: protocol.RoomSettings{Bots: 1}}
+	for id := 0; id < 2; id++ {
+		r.members = append(r.members, &roomMember{id: id, host: id == 0, ready: true, send: make(chan protocol.Message, 1)})
+	}
+	if err := r.start(); err != nil {
+		t.Fatal(err)
+	}
+
+	var game *Game
+	for id, m := range r.members {
+		start, ok := (<-m.send).(protocol.Start)
+		if !ok || start.Seat == "" {
+			t.Fatalf("Got %#v, want the game with a seat", start)
+		}
+		if game = activeGames.Get(start.Game); game == nil {
+			t.Fatalf("Game %s doesn't exist", start.Game)
+		}
+		if seat, err := game.claimSeat(start.Seat); err != nil || seat != id {
+			t.Errorf("Claimed seat %d, %v, want seat %d", seat, err, id)
+		}
+	}
+	// nobody without an invitation can join the private game
+	if id, ok := game.reserveSeat(); ok {
+		t.Errorf("Reserved seat %d of the private game", id)
+	}
+}
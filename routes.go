@@ -1,32 +1,82 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/lazareviczoran/blaster-twister/protocol"
 )
 
-var activeGames = make(map[string]*Game)
+// Number of games per page of the game browser
+const (
+	defaultGamesLimit = 20
+	maxGamesLimit     = 100
+)
 
 func createRouter() *mux.Router {
 	router := mux.NewRouter()
 	router.HandleFunc("/", serveHome)
 	router.HandleFunc("/join", serveLobby)
 	router.HandleFunc("/single-player", createSinglePlayerGame)
+	router.HandleFunc("/custom-game", createCustomGame)
 	router.HandleFunc("/g/{gameID}", serveGame)
+	router.HandleFunc("/games", serveGameBrowser)
+	router.HandleFunc("/api/games", listGames)
+	router.HandleFunc("/profile", serveProfile)
+	router.HandleFunc("/rooms", createPrivateRoom)
+	router.HandleFunc("/r", findPrivateRoom)
+	router.HandleFunc("/r/{code}", serveRoom)
+	router.HandleFunc("/protocol/schema.json", serveProtocolSchema)
 	router.HandleFunc("/ws/game/{gameID}", func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
 		key := vars["gameID"]
 
-		game := activeGames[key]
+		game := activeGames.Get(key)
 		if game != nil && !game.started {
 			connectPlayer(game, w, r)
 			return
 		}
 	})
+	router.HandleFunc("/ws/game/{gameID}/play", func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		key := vars["gameID"]
+
+		// started games are full, but their players can still resume
+		game := activeGames.Get(key)
+		if game == nil {
+			http.Error(w, "Not found", http.StatusNotFound)
+			return
+		}
+		connectMultiplexedPlayer(game, w, r)
+	})
+	router.HandleFunc("/ws/game/{gameID}/debug", func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		key := vars["gameID"]
+
+		game := activeGames.Get(key)
+		if game == nil {
+			http.Error(w, "Not found", http.StatusNotFound)
+			return
+		}
+		if !game.debug.allowed(r) {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			log.Print("upgrade:", err)
+			return
+		}
+		game.debug.subscribe(conn)
+	})
+	// command connection of the legacy clients, the clientID in the path
+	// is ignored, the seat token from the assigned message is required
 	router.HandleFunc("/ws/game/{gameID}/{clientID}/{playerID}", func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
 		key := vars["gameID"]
@@ -35,33 +85,58 @@ func createRouter() *mux.Router {
 			log.Printf("Couldn't parse playerID %s", vars["playerID"])
 			return
 		}
-		clientID, err := strconv.Atoi(vars["clientID"])
+
+		game := activeGames.Get(key)
+		if game == nil {
+			http.Error(w, "Not found", http.StatusNotFound)
+			return
+		}
+		player, err := game.seatedPlayer(r.URL.Query().Get("token"))
+		if err != nil || player.ID() != playerID {
+			log.Printf("Rejecting command connection to player %d of game %s, %v", playerID, key, err)
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
 		if err != nil {
-			log.Printf("Couldn't parse clientID %s", vars["clientID"])
-			return
-		}
-
-		game := activeGames[key]
-		if game != nil {
-			player := game.players[playerID]
-			if player != nil {
-				if player.ClientID() != clientID {
-					log.Printf("Client IDs don't match (%d != %d)", player.ClientID(), clientID)
-					return
-				}
-				conn, err := upgrader.Upgrade(w, r, nil)
-				if err != nil {
-					log.Print("upgrade:", err)
-					return
-				}
-				player.(*Human).AttachWriteConn(conn)
-				return
-			} else {
-				log.Printf("Couldn't find player %d in game %s)", playerID, key)
-			}
+			log.Print("upgrade:", err)
+			return
 		}
-		http.Error(w, "Not found", http.StatusNotFound)
+		player.AttachWriteConn(conn)
+	})
+	router.HandleFunc("/ws/room/{code}", func(w http.ResponseWriter, r *http.Request) {
+		room := findRoom(mux.Vars(r)["code"])
+		if room == nil {
+			http.Error(w, "Not found", http.StatusNotFound)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			log.Print("upgrade:", err)
+			return
+		}
+		room.join(conn, requestSession(r))
+	})
+	router.HandleFunc("/ws/rematch/{gameID}", func(w http.ResponseWriter, r *http.Request) {
+		rematch := findRematch(mux.Vars(r)["gameID"])
+		if rematch == nil {
+			http.Error(w, "Not found", http.StatusNotFound)
+			return
+		}
+		id, err := rematch.seat(r.URL.Query().Get("token"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			log.Print("upgrade:", err)
+			return
+		}
+		rematch.join(conn, id)
 	})
+	// "?party=new" creates a party and "?party={code}" joins one,
+	// otherwise the player waits alone
 	router.HandleFunc("/ws/lobby", func(w http.ResponseWriter, r *http.Request) {
 		conn, err := upgrader.Upgrade(w, r, nil)
 		if err != nil {
@@ -69,7 +144,15 @@ func createRouter() *mux.Router {
 			return
 		}
 
-		lobby.register <- newCandidate(conn)
+		c := newCandidate(lobby, conn, requestSession(r))
+		switch party := strings.ToUpper(strings.TrimSpace(r.URL.Query().Get("party"))); party {
+		case "":
+			lobby.Enqueue(c)
+		case "NEW":
+			lobby.JoinParty(c, "")
+		default:
+			lobby.JoinParty(c, party)
+		}
 	})
 
 	return router
@@ -97,14 +180,71 @@ func createSinglePlayerGame(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	gameID := randToken()
-	game := newGame(gameID, height, width)
-	activeGames[gameID] = game
-	go game.run()
+	bots, err := queryInt(r, "bots", 1)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	startGameWithBots(w, r, 1, bots)
+}
+
+// createCustomGame creates a game for any mix of humans and bots,
+// the humans join by opening the link of the game
+func createCustomGame(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	humans, err := queryInt(r, "humans", 1)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	bots, err := queryInt(r, "bots", 0)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	startGameWithBots(w, r, humans, bots)
+}
+
+// startGameWithBots creates a game with the bots from the request,
+// their personalities can be set with a comma separated "personalities" parameter
+// and "debug=1" streams the bots decisions to the game page
+func startGameWithBots(w http.ResponseWriter, r *http.Request, humans, bots int) {
+	if humans < 1 || bots < 0 || humans+bots < 2 {
+		http.Error(w, "A game needs at least one human and two players", http.StatusBadRequest)
+		return
+	}
+	botPersonalities, err := parsePersonalities(r.URL.Query().Get("personalities"), bots)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	game, err := createGame(humans + bots)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	game.bots = bots
+	if humans == 1 {
+		game.adaptive = true
+		game.botDifficulty = skills.difficulty(sessionID(w, r))
+	}
 
-	connectBot(game)
+	connectBots(game, botPersonalities)
+	// games for several humans are shared by their link, so anyone may join
+	if humans > 1 {
+		activeGames.List(game)
+	}
 
-	http.Redirect(w, r, fmt.Sprintf("/g/%s", gameID), http.StatusSeeOther)
+	if r.URL.Query().Get("debug") == "1" {
+		game.debug.enable()
+		http.Redirect(w, r, fmt.Sprintf("/g/%s?debug=1", game.id), http.StatusSeeOther)
+		return
+	}
+	http.Redirect(w, r, fmt.Sprintf("/g/%s", game.id), http.StatusSeeOther)
 }
 
 func serveLobby(w http.ResponseWriter, r *http.Request) {
@@ -116,9 +256,52 @@ func serveLobby(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
+	// the session identifies the rating of the player in the lobby
+	sessionID(w, r)
 	http.ServeFile(w, r, "./frontend/html/lobby.html")
 }
 
+// createPrivateRoom opens a private room hosted by the session of
+// the request. It answers JSON requests with the code and the link of
+// the room and redirects the others to the room.
+func createPrivateRoom(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	room := createRoom(sessionID(w, r))
+	if strings.Contains(r.Header.Get("Accept"), "application/json") {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"code": room.code, "link": room.link()})
+		return
+	}
+	http.Redirect(w, r, room.link(), http.StatusSeeOther)
+}
+
+// findPrivateRoom redirects to the room with the code from the query
+func findPrivateRoom(w http.ResponseWriter, r *http.Request) {
+	code := strings.ToUpper(strings.TrimSpace(r.URL.Query().Get("code")))
+	if findRoom(code) == nil {
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+	http.Redirect(w, r, fmt.Sprintf("/r/%s", code), http.StatusSeeOther)
+}
+
+func serveRoom(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if findRoom(mux.Vars(r)["code"]) == nil {
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+	// the session identifies the host and the profiles of the members
+	sessionID(w, r)
+	http.ServeFile(w, r, "./frontend/html/room.html")
+}
+
 func serveGame(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -127,10 +310,95 @@ func serveGame(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	key := vars["gameID"]
 
-	game := activeGames[key]
-	if game == nil || game.started {
+	// players of started games can still reload the page and resume
+	game := activeGames.Get(key)
+	if game == nil {
+		// games of other servers are played there
+		if host, ok := activeGames.Host(key); ok {
+			http.Redirect(w, r, host+"/g/"+key, http.StatusSeeOther)
+			return
+		}
 		http.Redirect(w, r, "/", http.StatusSeeOther)
 		return
 	}
 	http.ServeFile(w, r, "./frontend/html/game.html")
 }
+
+func serveGameBrowser(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	http.ServeFile(w, r, "./frontend/html/games.html")
+}
+
+// listGames answers with a page of the open public games, the oldest first.
+// They can be filtered by the number of players ("capacity"), the number of
+// free seats ("free") and whether they have bots ("bots=true" or "false"),
+// the page is selected with "offset" and "limit".
+func listGames(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var filter gameFilter
+	var err error
+	if filter.capacity, err = queryInt(r, "capacity", 0); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if filter.free, err = queryInt(r, "free", 1); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if value := r.URL.Query().Get("bots"); value != "" {
+		bots, err := strconv.ParseBool(value)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid value %q for bots", value), http.StatusBadRequest)
+			return
+		}
+		filter.bots = &bots
+	}
+	offset, err := queryInt(r, "offset", 0)
+	if err != nil || offset < 0 {
+		http.Error(w, "Invalid offset", http.StatusBadRequest)
+		return
+	}
+	limit, err := queryInt(r, "limit", defaultGamesLimit)
+	if err != nil || limit < 1 || limit > maxGamesLimit {
+		http.Error(w, fmt.Sprintf("The limit must be between 1 and %d", maxGamesLimit), http.StatusBadRequest)
+		return
+	}
+
+	games := activeGames.Open(filter, time.Now())
+	total := len(games)
+	if offset > total {
+		offset = total
+	}
+	if offset+limit < total {
+		games = games[offset : offset+limit]
+	} else {
+		games = games[offset:]
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(struct {
+		Games  []GameListing `json:"games"`
+		Total  int           `json:"total"`
+		Offset int           `json:"offset"`
+		Limit  int           `json:"limit"`
+	}{games, total, offset, limit})
+}
+
+func serveProtocolSchema(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	schema, err := protocol.Schema()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/schema+json")
+	w.Write(schema)
+}
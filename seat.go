@@ -0,0 +1,106 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// seatSecret signs the seat tokens. It is read from SEAT_SECRET, so that
+// several servers can check each others tokens, otherwise it is random.
+var seatSecret = loadSeatSecret()
+
+func loadSeatSecret() []byte {
+	if secret := os.Getenv("SEAT_SECRET"); secret != "" {
+		return []byte(secret)
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		log.Fatalf("Could not generate the seat secret, %v", err)
+	}
+	return secret
+}
+
+// newSeatToken issues the token of a players seat in the game. The token
+// contains the game, the player id and a random nonce, followed by their
+// signature, which makes it unguessable for everyone else.
+func newSeatToken(gameID string, playerID int) string {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		log.Fatalf("Could not generate a seat token, %v", err)
+	}
+	payload := fmt.Sprintf("%s.%d.%s", gameID, playerID, base64.RawURLEncoding.EncodeToString(nonce))
+	return payload + "." + signSeat(payload)
+}
+
+func signSeat(payload string) string {
+	mac := hmac.New(sha256.New, seatSecret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// parseSeatToken checks the signature of the token and returns its seat
+func parseSeatToken(token string) (gameID string, playerID int, err error) {
+	i := strings.LastIndex(token, ".")
+	if i < 0 {
+		return "", 0, errors.New("Malformed seat token")
+	}
+	payload, signature := token[:i], token[i+1:]
+	if !hmac.Equal([]byte(signature), []byte(signSeat(payload))) {
+		return "", 0, errors.New("Invalid seat token")
+	}
+	parts := strings.Split(payload, ".")
+	if len(parts) != 3 {
+		return "", 0, errors.New("Malformed seat token")
+	}
+	playerID, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return "", 0, errors.New("Malformed seat token")
+	}
+	return parts[0], playerID, nil
+}
+
+// seatedPlayer returns the human player holding the seat of the token
+func (g *Game) seatedPlayer(token string) (*Human, error) {
+	gameID, _, err := parseSeatToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if gameID != g.id {
+		return nil, errors.New("Seat token of another game")
+	}
+	g.seatsMu.Lock()
+	defer g.seatsMu.Unlock()
+	player, ok := g.seated[token]
+	if !ok {
+		return nil, errors.New("Unknown seat token")
+	}
+	return player, nil
+}
+
+// claimSeat takes the seat reserved for the token, e.g. by a private room or a rematch,
+// and returns its player id
+func (g *Game) claimSeat(token string) (int, error) {
+	gameID, _, err := parseSeatToken(token)
+	if err != nil {
+		return 0, err
+	}
+	if gameID != g.id {
+		return 0, errors.New("Seat token of another game")
+	}
+	g.seatsMu.Lock()
+	defer g.seatsMu.Unlock()
+	id, ok := g.invited[token]
+	if !ok {
+		return 0, errors.New("Unknown seat token")
+	}
+	delete(g.invited, token)
+	return id, nil
+}
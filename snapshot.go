@@ -0,0 +1,103 @@
+package main
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/lazareviczoran/blaster-twister/protocol"
+)
+
+// trailPoint is a position a player reached during a tick
+type trailPoint struct {
+	tick   int
+	player int
+	x      int
+	y      int
+	trace  bool
+}
+
+// snapshotHistory collects the positions of all players, so that each
+// client can get the changes since the last snapshot it acknowledged
+type snapshotHistory struct {
+	mu     sync.Mutex
+	tick   int
+	points []trailPoint
+}
+
+// addPoint records a new position of the player in the current tick
+func (s *snapshotHistory) addPoint(player, x, y int, trace bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.points = append(s.points, trailPoint{s.tick + 1, player, x, y, trace})
+}
+
+// advance finishes the current tick and returns it
+func (s *snapshotHistory) advance() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tick++
+	return s.tick
+}
+
+// current returns the last finished tick
+func (s *snapshotHistory) current() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tick
+}
+
+// since returns the points of each player after the base tick
+// up to and including the given tick
+func (s *snapshotHistory) since(base, tick int) map[int][][4]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	start := sort.Search(len(s.points), func(i int) bool { return s.points[i].tick > base })
+	points := make(map[int][][4]int)
+	for _, p := range s.points[start:] {
+		if p.tick > tick {
+			break
+		}
+		trace := 0
+		if p.trace {
+			trace = 1
+		}
+		points[p.player] = append(points[p.player], [4]int{p.tick, p.x, p.y, trace})
+	}
+	return points
+}
+
+// sendSnapshots finishes the current tick and sends its snapshot
+// to each human player
+func (g *Game) sendSnapshots() {
+	tick := g.history.advance()
+	states := make(map[int]protocol.PlayerState, len(g.players))
+	alive := make(map[int]bool, len(g.players))
+	for id, p := range g.players {
+		states[id] = p.State()
+		alive[id] = p.IsAlive()
+	}
+
+	// clients which acknowledged the same tick get the same snapshot
+	snapshots := make(map[int]protocol.Snapshot)
+	for _, p := range g.players {
+		h, ok := p.(*Human)
+		if !ok {
+			continue
+		}
+		base := h.snapshotBase()
+		snapshot, ok := snapshots[base]
+		if !ok {
+			points := g.history.since(base, tick)
+			snapshot = protocol.Snapshot{Tick: tick, Base: base, Players: make(map[int]protocol.SnapshotPlayer, len(states))}
+			for id, state := range states {
+				playerPoints := points[id]
+				if playerPoints == nil {
+					playerPoints = [][4]int{}
+				}
+				snapshot.Players[id] = protocol.SnapshotPlayer{PlayerState: state, Alive: alive[id], Points: playerPoints}
+			}
+			snapshots[base] = snapshot
+		}
+		h.sendSnapshot(snapshot)
+	}
+}
@@ -0,0 +1,274 @@
+// Package store contains a minimal client of the Redis protocol, which
+// is all the game servers need to share their lobby and games. It works
+// with Redis and any server speaking its protocol.
+package store
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// Timeout of establishing the connection to the server
+	dialTimeout = 5 * time.Second
+	// Timeout of a command and its reply, blocking commands
+	// have to wait for less than that
+	commandTimeout = 10 * time.Second
+)
+
+// ErrNil is returned for keys and fields which don't exist
+var ErrNil = errors.New("store: nil reply")
+
+// Error is an error reply of the server
+type Error string
+
+func (e Error) Error() string { return string(e) }
+
+// Client sends commands over a single connection, which is established
+// on first use and again after an error. It is safe for concurrent use,
+// but blocking commands hold the connection, so they need a client of
+// their own.
+type Client struct {
+	addr string
+	mu   sync.Mutex
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// NewClient returns a client of the server at the address
+func NewClient(addr string) *Client {
+	return &Client{addr: addr}
+}
+
+// Do sends the command and returns its reply, which is a string, an
+// int64, nil or a []interface{} of those
+func (c *Client) Do(args ...string) (interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.do(args...)
+}
+
+// do sends the command, the caller holds the lock
+func (c *Client) do(args ...string) (interface{}, error) {
+	if c.conn == nil {
+		conn, err := net.DialTimeout("tcp", c.addr, dialTimeout)
+		if err != nil {
+			return nil, err
+		}
+		c.conn, c.r = conn, bufio.NewReader(conn)
+	}
+	reply, err := c.roundTrip(args)
+	if err != nil {
+		if _, ok := err.(Error); !ok {
+			// the connection is in an unknown state
+			c.conn.Close()
+			c.conn = nil
+		}
+		return nil, err
+	}
+	return reply, nil
+}
+
+// Close closes the connection, the next command opens a new one
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
+
+func (c *Client) roundTrip(args []string) (interface{}, error) {
+	c.conn.SetDeadline(time.Now().Add(commandTimeout))
+	w := bufio.NewWriter(c.conn)
+	fmt.Fprintf(w, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(w, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if err := w.Flush(); err != nil {
+		return nil, err
+	}
+	return readReply(c.r)
+}
+
+func readReply(r *bufio.Reader) (interface{}, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("store: malformed reply %q", line)
+	}
+	kind, line := line[0], line[1:len(line)-2]
+	switch kind {
+	case '+':
+		return line, nil
+	case '-':
+		return nil, Error(line)
+	case ':':
+		return strconv.ParseInt(line, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line)
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		data := make([]byte, n+2)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		return string(data[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(line)
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		values := make([]interface{}, n)
+		for i := range values {
+			if values[i], err = readReply(r); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	}
+	return nil, fmt.Errorf("store: unknown reply type %q", kind)
+}
+
+// Get returns the value of the key, or ErrNil
+func (c *Client) Get(key string) (string, error) {
+	return str(c.Do("GET", key))
+}
+
+// Set sets the value of the key, which expires after the ttl unless it is 0
+func (c *Client) Set(key, value string, ttl time.Duration) error {
+	args := []string{"SET", key, value}
+	if ttl > 0 {
+		args = append(args, "PX", millis(ttl))
+	}
+	_, err := c.Do(args...)
+	return err
+}
+
+// SetNX sets the value of the key with the ttl unless the
+// key exists, it returns whether it was set
+func (c *Client) SetNX(key, value string, ttl time.Duration) (bool, error) {
+	reply, err := c.Do("SET", key, value, "PX", millis(ttl), "NX")
+	return reply != nil, err
+}
+
+// Expire changes the ttl of the key
+func (c *Client) Expire(key string, ttl time.Duration) error {
+	_, err := c.Do("PEXPIRE", key, millis(ttl))
+	return err
+}
+
+// ExpireIfEquals changes the ttl of the key if it has the value, and returns
+// whether it did. Checking the value and changing the ttl is a transaction,
+// which fails when someone else changes the key in between.
+func (c *Client) ExpireIfEquals(key, value string, ttl time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := c.do("WATCH", key); err != nil {
+		return false, err
+	}
+	current, err := str(c.do("GET", key))
+	if err != nil && err != ErrNil {
+		return false, err
+	}
+	if current != value || err == ErrNil {
+		_, err := c.do("UNWATCH")
+		return false, err
+	}
+	if _, err := c.do("MULTI"); err != nil {
+		return false, err
+	}
+	if _, err := c.do("PEXPIRE", key, millis(ttl)); err != nil {
+		c.do("DISCARD")
+		return false, err
+	}
+	// the transaction is aborted with a nil reply
+	reply, err := c.do("EXEC")
+	return reply != nil, err
+}
+
+// HSet sets the field of the hash
+func (c *Client) HSet(key, field, value string) error {
+	_, err := c.Do("HSET", key, field, value)
+	return err
+}
+
+// HGet returns the field of the hash, or ErrNil
+func (c *Client) HGet(key, field string) (string, error) {
+	return str(c.Do("HGET", key, field))
+}
+
+// HDel removes the field of the hash
+func (c *Client) HDel(key, field string) error {
+	_, err := c.Do("HDEL", key, field)
+	return err
+}
+
+// HGetAll returns all fields of the hash
+func (c *Client) HGetAll(key string) (map[string]string, error) {
+	reply, err := c.Do("HGETALL", key)
+	if err != nil {
+		return nil, err
+	}
+	values, _ := reply.([]interface{})
+	fields := make(map[string]string, len(values)/2)
+	for i := 0; i+1 < len(values); i += 2 {
+		field, _ := values[i].(string)
+		value, _ := values[i+1].(string)
+		fields[field] = value
+	}
+	return fields, nil
+}
+
+// RPush appends the value to the list
+func (c *Client) RPush(key, value string) error {
+	_, err := c.Do("RPUSH", key, value)
+	return err
+}
+
+// BLPop takes the first value of the list, waiting up to the timeout
+// for one to arrive, it returns ErrNil after the timeout
+func (c *Client) BLPop(key string, timeout time.Duration) (string, error) {
+	// a timeout of 0 would block forever
+	seconds := int(timeout.Seconds())
+	if seconds < 1 {
+		seconds = 1
+	}
+	reply, err := c.Do("BLPOP", key, strconv.Itoa(seconds))
+	if err != nil {
+		return "", err
+	}
+	values, _ := reply.([]interface{})
+	if len(values) != 2 {
+		return "", ErrNil
+	}
+	value, _ := values[1].(string)
+	return value, nil
+}
+
+func str(reply interface{}, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	s, ok := reply.(string)
+	if !ok {
+		return "", ErrNil
+	}
+	return s, nil
+}
+
+func millis(d time.Duration) string {
+	return strconv.FormatInt(int64(d/time.Millisecond), 10)
+}
@@ -0,0 +1,161 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lazareviczoran/blaster-twister/store/storetest"
+)
+
+// newTestClient returns a client of a new test server and
+// a function which closes both of them
+func newTestClient(t *testing.T) (*Client, *storetest.Server, func()) {
+	s, err := storetest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewClient(s.Addr())
+	return c, s, func() {
+		c.Close()
+		s.Close()
+	}
+}
+
+func TestStrings(t *testing.T) {
+	c, s, done := newTestClient(t)
+	defer done()
+	if _, err := c.Get("missing"); err != ErrNil {
+		t.Fatalf("Get of a missing key returned %v, want ErrNil", err)
+	}
+	if err := c.Set("key", "value", time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if value, err := c.Get("key"); err != nil || value != "value" {
+		t.Fatalf("Get returned %q, %v", value, err)
+	}
+	if ok, err := c.SetNX("key", "other", time.Second); err != nil || ok {
+		t.Fatalf("SetNX of an existing key returned %v, %v", ok, err)
+	}
+	s.FastForward(2 * time.Second)
+	if ok, err := c.SetNX("key", "other", time.Second); err != nil || !ok {
+		t.Fatalf("SetNX of an expired key returned %v, %v", ok, err)
+	}
+	if value, _ := c.Get("key"); value != "other" {
+		t.Fatalf("Get returned %q after SetNX", value)
+	}
+}
+
+func TestHashes(t *testing.T) {
+	c, _, done := newTestClient(t)
+	defer done()
+	if err := c.HSet("hash", "a", "1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.HSet("hash", "b", "2"); err != nil {
+		t.Fatal(err)
+	}
+	if value, err := c.HGet("hash", "a"); err != nil || value != "1" {
+		t.Fatalf("HGet returned %q, %v", value, err)
+	}
+	if _, err := c.HGet("hash", "c"); err != ErrNil {
+		t.Fatalf("HGet of a missing field returned %v, want ErrNil", err)
+	}
+	if err := c.HDel("hash", "a"); err != nil {
+		t.Fatal(err)
+	}
+	fields, err := c.HGetAll("hash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"b": "2"}; !reflect.DeepEqual(fields, want) {
+		t.Fatalf("HGetAll returned %v, want %v", fields, want)
+	}
+	if fields, err := c.HGetAll("missing"); err != nil || len(fields) != 0 {
+		t.Fatalf("HGetAll of a missing key returned %v, %v", fields, err)
+	}
+}
+
+func TestLists(t *testing.T) {
+	c, _, done := newTestClient(t)
+	defer done()
+	for _, value := range []string{"first", "second"} {
+		if err := c.RPush("list", value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, want := range []string{"first", "second"} {
+		if value, err := c.BLPop("list", time.Second); err != nil || value != want {
+			t.Fatalf("BLPop returned %q, %v, want %q", value, err, want)
+		}
+	}
+	if _, err := c.BLPop("list", time.Second); err != ErrNil {
+		t.Fatalf("BLPop of an empty list returned %v, want ErrNil", err)
+	}
+
+	// a blocked client gets the value pushed by another one
+	blocked := NewClient(c.addr)
+	defer blocked.Close()
+	values := make(chan string)
+	go func() {
+		value, _ := blocked.BLPop("list", 5*time.Second)
+		values <- value
+	}()
+	time.Sleep(50 * time.Millisecond)
+	c.RPush("list", "third")
+	if value := <-values; value != "third" {
+		t.Fatalf("blocked BLPop returned %q", value)
+	}
+}
+
+func TestExpireIfEquals(t *testing.T) {
+	c, s, done := newTestClient(t)
+	defer done()
+	if ok, err := c.ExpireIfEquals("lease", "a", time.Second); err != nil || ok {
+		t.Fatalf("ExpireIfEquals of a missing key returned %v, %v", ok, err)
+	}
+	c.Set("lease", "a", time.Second)
+	if ok, err := c.ExpireIfEquals("lease", "b", 10*time.Second); err != nil || ok {
+		t.Fatalf("ExpireIfEquals with another value returned %v, %v", ok, err)
+	}
+	if ok, err := c.ExpireIfEquals("lease", "a", 10*time.Second); err != nil || !ok {
+		t.Fatalf("ExpireIfEquals with the value returned %v, %v", ok, err)
+	}
+	s.FastForward(5 * time.Second)
+	if value, ok := s.Get("lease"); !ok || value != "a" {
+		t.Fatalf("the extended key is %q, %v", value, ok)
+	}
+	s.FastForward(10 * time.Second)
+	if ok, err := c.ExpireIfEquals("lease", "a", 10*time.Second); err != nil || ok {
+		t.Fatalf("ExpireIfEquals of an expired key returned %v, %v", ok, err)
+	}
+	if _, ok := s.Get("lease"); ok {
+		t.Fatal("ExpireIfEquals brought an expired key back")
+	}
+}
+
+func TestErrorReply(t *testing.T) {
+	c, _, done := newTestClient(t)
+	defer done()
+	_, err := c.Do("NOSUCHCOMMAND")
+	if _, ok := err.(Error); !ok {
+		t.Fatalf("unknown command returned %v, want an Error", err)
+	}
+	// the connection stays usable after an error reply
+	if reply, err := c.Do("PING"); err != nil || reply != "PONG" {
+		t.Fatalf("PING returned %v, %v", reply, err)
+	}
+}
+
+func TestTransactionAborted(t *testing.T) {
+	c, s, done := newTestClient(t)
+	defer done()
+	c.Set("lease", "a", time.Second)
+	c.Do("WATCH", "lease")
+	s.Set("lease", "b")
+	c.Do("MULTI")
+	c.Do("PEXPIRE", "lease", "10000")
+	if reply, err := c.Do("EXEC"); err != nil || reply != nil {
+		t.Fatalf("EXEC after a change of a watched key returned %v, %v", reply, err)
+	}
+}
@@ -0,0 +1,443 @@
+// Package storetest runs a Redis compatible server in memory for tests.
+// It speaks enough of the protocol for the commands of the store package,
+// and its clock can be moved forward to expire keys without waiting.
+package storetest
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Server is a Redis compatible server listening on a local port
+type Server struct {
+	listener net.Listener
+	mu       sync.Mutex
+	// signalled when a list gets a value or the clock moves
+	changed *sync.Cond
+	// time added to the clock by FastForward
+	offset  time.Duration
+	strings map[string]string
+	expiry  map[string]time.Time
+	hashes  map[string]map[string]string
+	lists   map[string][]string
+	// number of changes of each key, used by WATCH
+	versions map[string]int
+	closed   bool
+}
+
+// NewServer starts a server on a random local port
+func NewServer() (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{
+		listener: listener,
+		strings:  make(map[string]string),
+		expiry:   make(map[string]time.Time),
+		hashes:   make(map[string]map[string]string),
+		lists:    make(map[string][]string),
+		versions: make(map[string]int),
+	}
+	s.changed = sync.NewCond(&s.mu)
+	go s.accept()
+	go s.tick()
+	return s, nil
+}
+
+// Addr returns the address the server listens on
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Close stops the server
+func (s *Server) Close() {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+	s.listener.Close()
+	s.changed.Broadcast()
+}
+
+// FastForward moves the clock of the server, keys whose
+// ttl runs out in the meantime expire
+func (s *Server) FastForward(d time.Duration) {
+	s.mu.Lock()
+	s.offset += d
+	s.mu.Unlock()
+	s.changed.Broadcast()
+}
+
+// Get returns the value of the key, or false if it doesn't exist
+func (s *Server) Get(key string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, ok := s.stringValue(key)
+	return value, ok
+}
+
+// Set sets the value of the key without a ttl
+func (s *Server) Set(key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.strings[key] = value
+	delete(s.expiry, key)
+	s.versions[key]++
+}
+
+// List returns the values of the list
+func (s *Server) List(key string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.lists[key]...)
+}
+
+// tick wakes up the blocked commands, so they notice their timeouts
+func (s *Server) tick() {
+	ticker := time.NewTicker(20 * time.Millisecond)
+	defer ticker.Stop()
+	for range ticker.C {
+		s.mu.Lock()
+		closed := s.closed
+		s.mu.Unlock()
+		if closed {
+			return
+		}
+		s.changed.Broadcast()
+	}
+}
+
+func (s *Server) now() time.Time {
+	return time.Now().Add(s.offset)
+}
+
+// stringValue returns the string of the key, expiring it if its
+// ttl ran out, the caller holds the lock
+func (s *Server) stringValue(key string) (string, bool) {
+	if t, ok := s.expiry[key]; ok && !s.now().Before(t) {
+		delete(s.strings, key)
+		delete(s.expiry, key)
+		s.versions[key]++
+	}
+	value, ok := s.strings[key]
+	return value, ok
+}
+
+func (s *Server) accept() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.serve(conn)
+	}
+}
+
+// session is the state of a connection
+type session struct {
+	watched map[string]int
+	multi   bool
+	queued  [][]string
+}
+
+func (s *Server) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+	sess := &session{}
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		s.handle(w, sess, args)
+		if err := w.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[0] != '*' {
+		return nil, fmt.Errorf("unexpected %q", line)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil || n < 1 {
+		return nil, fmt.Errorf("unexpected %q", line)
+	}
+	args := make([]string, n)
+	for i := range args {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+		if err != nil || line[0] != '$' || size < 0 {
+			return nil, fmt.Errorf("unexpected %q", line)
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		args[i] = string(data[:size])
+	}
+	return args, nil
+}
+
+// reply is a reply of a command: a string, an int, an error,
+// nil or a []reply
+type reply interface{}
+
+type status string
+
+type replyError string
+
+func (s *Server) handle(w *bufio.Writer, sess *session, args []string) {
+	name := strings.ToUpper(args[0])
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case name == "MULTI":
+		sess.multi = true
+		sess.queued = nil
+		writeReply(w, status("OK"))
+	case name == "DISCARD":
+		sess.multi = false
+		sess.queued = nil
+		sess.watched = nil
+		writeReply(w, status("OK"))
+	case name == "EXEC":
+		writeReply(w, s.exec(sess))
+	case sess.multi:
+		sess.queued = append(sess.queued, args)
+		writeReply(w, status("QUEUED"))
+	case name == "WATCH":
+		if sess.watched == nil {
+			sess.watched = make(map[string]int)
+		}
+		for _, key := range args[1:] {
+			s.stringValue(key)
+			sess.watched[key] = s.versions[key]
+		}
+		writeReply(w, status("OK"))
+	case name == "UNWATCH":
+		sess.watched = nil
+		writeReply(w, status("OK"))
+	case name == "BLPOP":
+		writeReply(w, s.blpop(args))
+	default:
+		writeReply(w, s.command(args))
+	}
+}
+
+// exec runs the queued commands unless a watched key has changed
+func (s *Server) exec(sess *session) reply {
+	watched, queued := sess.watched, sess.queued
+	sess.multi, sess.queued, sess.watched = false, nil, nil
+	for key, version := range watched {
+		s.stringValue(key)
+		if s.versions[key] != version {
+			return nil
+		}
+	}
+	replies := make([]reply, len(queued))
+	for i, args := range queued {
+		replies[i] = s.command(args)
+	}
+	return replies
+}
+
+// blpop waits for a value while the lock is released by the condition
+func (s *Server) blpop(args []string) reply {
+	if len(args) != 3 {
+		return replyError("ERR wrong number of arguments for 'blpop' command")
+	}
+	seconds, err := strconv.Atoi(args[2])
+	if err != nil {
+		return replyError("ERR timeout is not an integer")
+	}
+	key := args[1]
+	deadline := time.Now().Add(time.Duration(seconds) * time.Second)
+	for len(s.lists[key]) == 0 && time.Now().Before(deadline) && !s.closed {
+		s.changed.Wait()
+	}
+	if len(s.lists[key]) == 0 {
+		return nil
+	}
+	value := s.lists[key][0]
+	s.lists[key] = s.lists[key][1:]
+	return []reply{key, value}
+}
+
+func (s *Server) command(args []string) reply {
+	name := strings.ToUpper(args[0])
+	switch name {
+	case "PING":
+		return status("PONG")
+	case "GET":
+		if len(args) != 2 {
+			break
+		}
+		if value, ok := s.stringValue(args[1]); ok {
+			return value
+		}
+		return nil
+	case "SET":
+		if len(args) < 3 {
+			break
+		}
+		return s.set(args[1], args[2], args[3:])
+	case "DEL":
+		deleted := 0
+		for _, key := range args[1:] {
+			_, isString := s.stringValue(key)
+			_, isHash := s.hashes[key]
+			_, isList := s.lists[key]
+			if isString || isHash || isList {
+				deleted++
+			}
+			delete(s.strings, key)
+			delete(s.expiry, key)
+			delete(s.hashes, key)
+			delete(s.lists, key)
+			s.versions[key]++
+		}
+		return deleted
+	case "PEXPIRE":
+		if len(args) != 3 {
+			break
+		}
+		ms, err := strconv.Atoi(args[2])
+		if err != nil {
+			return replyError("ERR value is not an integer or out of range")
+		}
+		if _, ok := s.stringValue(args[1]); !ok {
+			return 0
+		}
+		s.expiry[args[1]] = s.now().Add(time.Duration(ms) * time.Millisecond)
+		s.versions[args[1]]++
+		return 1
+	case "HSET":
+		if len(args) < 4 || len(args)%2 != 0 {
+			break
+		}
+		hash := s.hashes[args[1]]
+		if hash == nil {
+			hash = make(map[string]string)
+			s.hashes[args[1]] = hash
+		}
+		added := 0
+		for i := 2; i < len(args); i += 2 {
+			if _, ok := hash[args[i]]; !ok {
+				added++
+			}
+			hash[args[i]] = args[i+1]
+		}
+		return added
+	case "HGET":
+		if len(args) != 3 {
+			break
+		}
+		if value, ok := s.hashes[args[1]][args[2]]; ok {
+			return value
+		}
+		return nil
+	case "HDEL":
+		if len(args) < 3 {
+			break
+		}
+		deleted := 0
+		for _, field := range args[2:] {
+			if _, ok := s.hashes[args[1]][field]; ok {
+				delete(s.hashes[args[1]], field)
+				deleted++
+			}
+		}
+		return deleted
+	case "HGETALL":
+		if len(args) != 2 {
+			break
+		}
+		fields := []reply{}
+		for field, value := range s.hashes[args[1]] {
+			fields = append(fields, field, value)
+		}
+		return fields
+	case "RPUSH":
+		if len(args) < 3 {
+			break
+		}
+		s.lists[args[1]] = append(s.lists[args[1]], args[2:]...)
+		s.changed.Broadcast()
+		return len(s.lists[args[1]])
+	default:
+		return replyError(fmt.Sprintf("ERR unknown command '%s'", args[0]))
+	}
+	return replyError(fmt.Sprintf("ERR wrong number of arguments for '%s' command", strings.ToLower(name)))
+}
+
+func (s *Server) set(key, value string, options []string) reply {
+	var ttl time.Duration
+	nx, xx := false, false
+	for i := 0; i < len(options); i++ {
+		switch strings.ToUpper(options[i]) {
+		case "NX":
+			nx = true
+		case "XX":
+			xx = true
+		case "PX":
+			if i+1 == len(options) {
+				return replyError("ERR syntax error")
+			}
+			ms, err := strconv.Atoi(options[i+1])
+			if err != nil || ms <= 0 {
+				return replyError("ERR invalid expire time in 'set' command")
+			}
+			ttl = time.Duration(ms) * time.Millisecond
+			i++
+		default:
+			return replyError("ERR syntax error")
+		}
+	}
+	_, exists := s.stringValue(key)
+	if (nx && exists) || (xx && !exists) {
+		return nil
+	}
+	s.strings[key] = value
+	delete(s.expiry, key)
+	if ttl > 0 {
+		s.expiry[key] = s.now().Add(ttl)
+	}
+	s.versions[key]++
+	return status("OK")
+}
+
+func writeReply(w *bufio.Writer, r reply) {
+	switch r := r.(type) {
+	case nil:
+		w.WriteString("$-1\r\n")
+	case status:
+		fmt.Fprintf(w, "+%s\r\n", r)
+	case replyError:
+		fmt.Fprintf(w, "-%s\r\n", r)
+	case int:
+		fmt.Fprintf(w, ":%d\r\n", r)
+	case string:
+		fmt.Fprintf(w, "$%d\r\n%s\r\n", len(r), r)
+	case []reply:
+		fmt.Fprintf(w, "*%d\r\n", len(r))
+		for _, item := range r {
+			writeReply(w, item)
+		}
+	}
+}
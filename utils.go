@@ -3,20 +3,11 @@ package main
 import (
 	"fmt"
 	"math/rand"
-	"sync"
+	"net/http"
+	"strconv"
 	"time"
 )
 
-func syncMapToMap(m *sync.Map) map[string]interface{} {
-	temp := make(map[string]interface{})
-	m.Range(func(k interface{}, v interface{}) bool {
-		temp[k.(string)] = v
-		return true
-	})
-
-	return temp
-}
-
 func getStartRotation() int {
 	return rand.Intn(90)
 }
@@ -27,6 +18,11 @@ func randToken() string {
 	return fmt.Sprintf("%x", b)
 }
 
+// unixMillis returns the time in milliseconds since the epoch
+func unixMillis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
 func randomIntFromRange(min int, max int) int {
 	return rand.Intn(max-min) + min
 }
@@ -35,3 +31,17 @@ func createRandomIntervalTicker(min, max int) *time.Ticker {
 	interval := randomIntFromRange(min, max)
 	return time.NewTicker(time.Duration(interval) * time.Millisecond)
 }
+
+// queryInt returns the integer value of the query parameter,
+// or the default value if the parameter is not set
+func queryInt(r *http.Request, key string, defaultValue int) (int, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid value %q for %s", value, key)
+	}
+	return i, nil
+}
@@ -5,7 +5,9 @@ require('@babel/register');
 const config = {
   entry: {
     game: './frontend/scripts/game.js',
+    games: './frontend/scripts/games.js',
     lobby: './frontend/scripts/lobby.js',
+    room: './frontend/scripts/room.js',
   },
   output: {
     path: `${__dirname}/dist`,